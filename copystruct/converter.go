@@ -0,0 +1,140 @@
+package copystruct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// converters keeps converters by their source and destination types, it is safe for concurrent use.
+type converters struct {
+	mu  sync.RWMutex
+	fns map[[2]reflect.Type]reflect.Value
+}
+
+// nolint:gochecknoglobals
+var globalConverters = &converters{}
+
+// nolint:gochecknoglobals
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RegisterConverter registers a converter globally, it is a func like func(time.Time) (string, error).
+// The converter is used for any field whose source and destination types match the func,
+// its error is returned by To or From.
+func RegisterConverter(fn interface{}) error {
+	return globalConverters.register(fn)
+}
+
+// Converter registers a converter only for the CopyStruct, see RegisterConverter.
+// An invalid converter is reported by To or From.
+func Converter(fn interface{}) OptionFn {
+	return func(cs *CopyStruct) {
+		if cs.converters == nil {
+			cs.converters = &converters{}
+		}
+
+		if err := cs.converters.register(fn); err != nil && cs.err == nil {
+			cs.err = err
+		}
+	}
+}
+
+func (c *converters) register(fn interface{}) error {
+	v := reflect.ValueOf(fn)
+	if !v.IsValid() || v.Kind() != reflect.Func || v.IsNil() {
+		return fmt.Errorf("converter %T should be like func(Src) (Dst, error)", fn)
+	}
+
+	t := v.Type()
+	if t.NumIn() != 1 || t.NumOut() != 2 || t.Out(1) != errorType { // nolint:gomnd
+		return fmt.Errorf("converter %T should be like func(Src) (Dst, error)", fn)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fns == nil {
+		c.fns = make(map[[2]reflect.Type]reflect.Value)
+	}
+
+	c.fns[[2]reflect.Type{t.Out(0), t.In(0)}] = v
+
+	return nil
+}
+
+func (c *converters) converter(dst, src reflect.Type) (reflect.Value, bool) {
+	if c == nil {
+		return reflect.Value{}, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fn, ok := c.fns[[2]reflect.Type{dst, src}]
+
+	return fn, ok
+}
+
+// lookupConverter finds a converter registered for the CopyStruct, then a global one.
+func (dc *CopyStruct) lookupConverter(dst, src reflect.Type) (reflect.Value, bool) {
+	if fn, ok := dc.converters.converter(dst, src); ok {
+		return fn, true
+	}
+
+	return globalConverters.converter(dst, src)
+}
+
+func (dc *CopyStruct) hasConverter(dst, src reflect.Type) bool {
+	_, ok := dc.lookupConverter(dst, src)
+	return ok
+}
+
+// convert sets dst by a converter registered for the types of dst and src.
+// It returns false if there is no such converter.
+func (dc *CopyStruct) convert(dst, src reflect.Value) (bool, error) {
+	fn, ok := dc.lookupConverter(dst.Type(), src.Type())
+	if !ok {
+		return false, nil
+	}
+
+	out := fn.Call([]reflect.Value{src})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return true, err
+	}
+
+	dst.Set(out[0])
+
+	return true, nil
+}
+
+// FieldError is an error of copying a field to the destination.
+type FieldError struct {
+	// Field is the path of the destination field, e.g. Items[0].Address.
+	Field string
+	Err   error
+}
+
+// Error returns the error message.
+func (e *FieldError) Error() string { return "copy field " + e.Field + ": " + e.Err.Error() }
+
+// Unwrap returns the error of the converter.
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// withField prefixes the path of a FieldError with a field name or an index like [0].
+func withField(err error, field string) error {
+	if err == nil {
+		return nil
+	}
+
+	fe, ok := err.(*FieldError)
+	if !ok {
+		return &FieldError{Field: field, Err: err}
+	}
+
+	if !strings.HasPrefix(fe.Field, "[") {
+		field += "."
+	}
+
+	return &FieldError{Field: field + fe.Field, Err: fe.Err}
+}
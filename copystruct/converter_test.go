@@ -0,0 +1,106 @@
+package copystruct_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bingoohuang/gor/copystruct"
+	uuid "github.com/satori/go.uuid"
+	assert "github.com/stretchr/testify/require"
+)
+
+type OrderStatus int
+
+const (
+	OrderNew OrderStatus = iota
+	OrderPaid
+)
+
+func (s OrderStatus) String() string { return [...]string{"new", "paid"}[s] }
+
+type Order struct {
+	ID        uuid.UUID
+	Status    OrderStatus
+	CreatedAt time.Time
+	Lines     []OrderLine
+}
+
+type OrderLine struct {
+	Status OrderStatus
+}
+
+type OrderDTO struct {
+	ID        string
+	Status    string
+	CreatedAt string
+	Lines     []OrderLineDTO
+}
+
+type OrderLineDTO struct {
+	Status string
+}
+
+func TestConverter(t *testing.T) {
+	assert.Nil(t, copystruct.RegisterConverter(func(s OrderStatus) (string, error) { return s.String(), nil }))
+	assert.Nil(t, copystruct.RegisterConverter(func(s string) (OrderStatus, error) {
+		for _, status := range []OrderStatus{OrderNew, OrderPaid} {
+			if status.String() == s {
+				return status, nil
+			}
+		}
+
+		return 0, fmt.Errorf("unknown status %q", s)
+	}))
+
+	id, _ := uuid.NewV4()
+	created := time.Date(2020, 2, 29, 13, 30, 0, 0, time.UTC)
+	src := &Order{ID: id, Status: OrderPaid, CreatedAt: created, Lines: []OrderLine{{Status: OrderNew}}}
+
+	rfc3339 := copystruct.Converter(func(t time.Time) (string, error) { return t.Format(time.RFC3339), nil })
+	uuidString := copystruct.Converter(func(u uuid.UUID) (string, error) { return u.String(), nil })
+
+	//
+	// To()
+	//
+
+	dst := &OrderDTO{}
+	assert.Nil(t, copystruct.Copy(src, rfc3339, uuidString).To(dst))
+	assert.Equal(t, OrderDTO{
+		ID:        id.String(),
+		Status:    "paid",
+		CreatedAt: "2020-02-29T13:30:00Z",
+		Lines:     []OrderLineDTO{{Status: "new"}},
+	}, *dst)
+
+	dst = &OrderDTO{}
+	assert.Nil(t, copystruct.Copy(src).To(dst))
+	assert.Empty(t, dst.CreatedAt, "converter of an option is used globally")
+	assert.Empty(t, dst.ID, "a nullable type is not converted without force option")
+
+	//
+	// From()
+	//
+
+	back := &Order{}
+	assert.Nil(t, copystruct.Copy(back).From(&OrderDTO{Status: "paid", Lines: []OrderLineDTO{{Status: "new"}}}))
+	assert.Equal(t, OrderPaid, back.Status)
+	assert.Equal(t, []OrderLine{{Status: OrderNew}}, back.Lines)
+
+	err := copystruct.Copy(back).From(&OrderDTO{Status: "paid", Lines: []OrderLineDTO{{Status: "lost"}}})
+	assert.EqualError(t, err, `copy field Lines[0].Status: unknown status "lost"`)
+
+	var fe *copystruct.FieldError
+	assert.True(t, errors.As(err, &fe))
+	assert.Equal(t, "Lines[0].Status", fe.Field)
+}
+
+func TestConverter_Invalid(t *testing.T) {
+	assert.NotNil(t, copystruct.RegisterConverter(nil))
+	assert.NotNil(t, copystruct.RegisterConverter(func(int) string { return "" }))
+	assert.NotNil(t, copystruct.RegisterConverter("not a func"))
+
+	err := copystruct.Copy(&Order{}, copystruct.Converter(func(int, int) (string, error) { return "", nil })).To(&OrderDTO{})
+	assert.NotNil(t, err)
+}
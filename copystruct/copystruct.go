@@ -31,6 +31,17 @@ type CopyStruct struct {
 	dst, src interface{}
 	ctx      map[string]interface{}
 	tagName  string
+	// visited contains mapped pointers of the source, see deepCopy.
+	visited map[visit]reflect.Value
+	// converters are registered by Converter option.
+	converters *converters
+	// err is an error of options, it is returned by To or From.
+	err error
+	// strict and reporting are set by Strict and WithReport options, report is collected by any of them.
+	strict, reporting bool
+	report            *Report
+	// srcPath and dstPath are names of the fields on the way, for reporting.
+	srcPath, dstPath []string
 }
 
 // TagName customizes the tagName (default is copystruct)
@@ -74,43 +85,93 @@ func (dc *CopyStruct) From(src interface{}) error {
 
 // process handles copy.
 func (dc *CopyStruct) process(dst, src interface{}, reversed bool) error {
-	dstValue := reflect.Indirect(reflect.ValueOf(dst))
-	if !dstValue.CanAddr() {
+	if dc.err != nil {
+		return dc.err
+	}
+
+	dstValue, srcValue := reflect.Indirect(reflect.ValueOf(dst)), reflect.ValueOf(src)
+	if !dstValue.CanAddr() && (dstValue.Kind() != reflect.Map || dstValue.IsNil()) {
 		return fmt.Errorf("destination %+v is unaddressable", dstValue.Interface())
 	}
 
-	srcValue := reflect.Indirect(reflect.ValueOf(src))
+	dc.visited = make(map[visit]reflect.Value)
+	dc.report, dc.srcPath, dc.dstPath = nil, nil, nil
 
-	for _, f := range getFieldNames(src) {
-		dc.copyFields(srcValue, dstValue, f, dst, reversed)
+	if dc.strict || dc.reporting {
+		dc.report = &Report{}
 	}
 
-	for _, m := range getMethodNames(src) {
-		if err := dc.copyMethods(dstValue, src, dst, m); err != nil {
-			return err
-		}
+	if err := dc.copyTop(dstValue, srcValue, reflect.ValueOf(dst), reversed); err != nil {
+		return err
+	}
+
+	if dc.strict {
+		return dc.report.strictError()
 	}
 
 	return nil
 }
 
-func (dc *CopyStruct) copyMethods(dstValue reflect.Value, src, dst interface{}, m string) error {
-	name, tagOptions := dc.getRelatedField(dst, m)
-	if name == "" {
-		return nil
+// copyTop copies src to dstValue, any of them could be a map with string keys instead of a struct.
+func (dc *CopyStruct) copyTop(dstValue, srcValue, dstPtr reflect.Value, reversed bool) error {
+	if isStringMap(dstValue.Type()) {
+		return dc.structToMap(dstValue, srcValue)
 	}
 
-	if _, ok := tagOptions[optionSkip]; ok {
-		return nil
+	if src := reflect.Indirect(srcValue); src.IsValid() && isStringMap(src.Type()) {
+		return dc.mapToStruct(dstValue, src)
 	}
 
-	method := reflect.ValueOf(src).MethodByName(m)
-	if !method.IsValid() {
-		return fmt.Errorf("method %s is invalid", m)
+	if srcValue.Kind() == reflect.Ptr && dstPtr.Kind() == reflect.Ptr && !srcValue.IsNil() {
+		dc.visited[visit{ptr: srcValue.Pointer(), typ: dstPtr.Type()}] = dstPtr
 	}
 
-	dstFieldType, _ := dstValue.Type().FieldByName(name)
-	dstFieldValue := dstValue.FieldByName(name)
+	return dc.copyStruct(dstValue, srcValue, reversed)
+}
+
+// copyStruct copies fields and methods of src, which is a struct or a pointer to a struct, to the struct dstValue.
+func (dc *CopyStruct) copyStruct(dstValue, src reflect.Value, reversed bool) error {
+	srcValue := reflect.Indirect(src)
+	if src.Kind() != reflect.Ptr && src.CanAddr() {
+		// methods with pointer receivers are available on addressable values
+		src = src.Addr()
+	}
+
+	plan := dc.getStructPlan(dstValue.Type(), src.Type(), reversed)
+	reported := dc.reported()
+
+	for i := range plan.fields {
+		p := &plan.fields[i]
+		status, err := dc.copyFields(srcValue, dstValue, p, reversed)
+
+		if dc.record(p.src.Name, p.dstName, status, err); err != nil {
+			return withField(err, p.dstName)
+		}
+	}
+
+	for i := range plan.methods {
+		p := &plan.methods[i]
+		status, err := dc.copyMethods(dstValue, src, p)
+
+		if dc.record(p.name+"()", p.dst.Name, status, err); err != nil {
+			return withField(err, p.dst.Name)
+		}
+	}
+
+	dc.recordUnpopulated(plan.dstFields, reported)
+
+	return nil
+}
+
+// copyMethods sets the result of a method of src to its related field of the destination.
+func (dc *CopyStruct) copyMethods(dstValue, src reflect.Value, p *methodPlan) (FieldStatus, error) {
+	if _, ok := p.options[optionSkip]; ok {
+		return FieldSkipped, nil
+	}
+
+	dstFieldType := p.dst
+	dstFieldValue := dstValue.FieldByIndex(p.dst.Index)
+	tagOptions := p.options
 
 	_, force := tagOptions[optionForce]
 
@@ -120,7 +181,7 @@ func (dc *CopyStruct) copyMethods(dstValue reflect.Value, src, dst interface{},
 		args = []reflect.Value{reflect.ValueOf(dc.ctx)}
 	}
 
-	resultValue := method.Call(args)[0]
+	resultValue := src.Method(p.index).Call(args)[0]
 	resultType := resultValue.Type()
 
 	// Value -> Ptr
@@ -128,105 +189,110 @@ func (dc *CopyStruct) copyMethods(dstValue reflect.Value, src, dst interface{},
 		ptr := reflect.New(resultType)
 		ptr.Elem().Set(resultValue)
 
-		setFieldValue(ptr.Type(), dstFieldType.Type, dstFieldValue, ptr, tagOptions)
+		ok, err := dc.setFieldValue(ptr.Type(), dstFieldType.Type, dstFieldValue, ptr, tagOptions)
 
-		return nil
+		return fieldStatus(ok && err == nil, dstFieldType.Type, ptr.Type()), err
 	}
 
 	// Ptr -> value
 	if resultValue.Kind() == reflect.Ptr && force {
-		setFieldValue(resultValue.Elem().Type(), dstFieldType.Type, dstFieldValue, resultValue.Elem(), tagOptions)
+		elem := resultValue.Elem()
+		ok, err := dc.setFieldValue(elem.Type(), dstFieldType.Type, dstFieldValue, elem, tagOptions)
 
-		return nil
+		return fieldStatus(ok && err == nil, dstFieldType.Type, resultType), err
 	}
 
-	if resultValue.IsValid() {
-		setFieldValue(resultType, dstFieldType.Type, dstFieldValue, resultValue, tagOptions)
-	}
+	ok, err := dc.setFieldValue(resultType, dstFieldType.Type, dstFieldValue, resultValue, tagOptions)
 
-	return nil
+	return fieldStatus(ok && err == nil, dstFieldType.Type, resultType), err
 }
 
-func (dc *CopyStruct) copyFields(srcValue, dstValue reflect.Value, f string, dst interface{}, reversed bool) {
-	srcFieldStruct, srcFieldFound := srcValue.Type().FieldByName(f)
-	if !srcFieldFound {
-		return
-	}
-
-	srcFieldValue := srcValue.FieldByName(f)
-	srcFieldType := srcFieldStruct.Type
-	srcFieldName := srcFieldStruct.Name
-
-	dstFieldName, tagOptions := dc.parseDstFieldName(srcFieldName, reversed, srcFieldStruct, dst)
-	if _, ok := tagOptions[optionSkip]; ok {
-		return
-	}
-
-	dstStructField, dstFieldFound := dstValue.Type().FieldByName(dstFieldName)
-	if !dstFieldFound {
-		return
+// copyFields copies a field of the struct srcValue to its destination field.
+func (dc *CopyStruct) copyFields(srcValue, dstValue reflect.Value, p *fieldPlan, reversed bool) (FieldStatus, error) {
+	if p.status != "" {
+		return p.status, nil
 	}
 
-	dstFieldValue := dstValue.FieldByName(dstFieldName)
+	srcFieldValue := srcValue.FieldByIndex(p.src.Index)
+	srcFieldType := p.src.Type
+	dstStructField := p.dst
+	dstFieldValue := dstValue.FieldByIndex(p.dst.Index)
+	tagOptions := p.options
 
 	// Force option for empty interfaces and nullable types
 	_, force := tagOptions[optionForce]
 
 	dstKind := dstFieldValue.Kind()
 	if isNullableType(srcFieldType) {
+		var ok bool
+
+		var err error
+
 		if dstKind == reflect.Ptr && force { // Valuer -> ptr
-			processNullableTypeValuer2Ptr(srcFieldValue, dstFieldValue, dstStructField, tagOptions)
+			ok, err = dc.processNullableTypeValuer2Ptr(srcFieldValue, dstFieldValue, dstStructField, tagOptions)
 		} else { // Valuer -> value
-			processNullableTypeValuer2Value(srcFieldValue, dstFieldValue, dstStructField, force, tagOptions)
+			ok, err = dc.processNullableTypeValuer2Value(srcFieldValue, dstFieldValue, dstStructField, force, tagOptions)
 		}
 
-		return
+		return fieldStatus(ok && err == nil, dstStructField.Type, srcFieldType), err
 	}
 
 	if dstKind == reflect.Interface {
-		if force {
-			dstFieldValue.Set(srcFieldValue)
+		if !force {
+			return FieldSkipped, nil
 		}
 
-		return
+		dstFieldValue.Set(srcFieldValue)
+
+		return FieldCopied, nil
 	}
 
 	// Ptr -> Value
 	if srcFieldType.Kind() == reflect.Ptr && !srcFieldValue.IsNil() && dstKind != reflect.Ptr {
 		srcFieldValue = reflect.Indirect(srcFieldValue)
-		srcFieldType = srcFieldValue.Type()
 	}
 
-	setFieldValue(srcFieldType, dstStructField.Type, dstFieldValue, srcFieldValue, tagOptions)
+	dc.enter(p.src.Name, p.dstName)
+	ok, err := dc.copyValue(dstFieldValue, srcFieldValue, tagOptions, reversed)
+	dc.leave()
+
+	return fieldStatus(ok && err == nil, dstStructField.Type, srcFieldValue.Type()), err
 }
 
-func setFieldValue(srcFieldType reflect.Type, dstFieldType reflect.Type,
+// setFieldValue sets the destination field by a registered converter, an assignment or a conversion.
+// It returns false if the types do not match.
+func (dc *CopyStruct) setFieldValue(srcFieldType reflect.Type, dstFieldType reflect.Type,
 	dstFieldValue, srcFieldValue reflect.Value, tagOptions tagOptions,
-) bool {
+) (bool, error) {
+	if ok, err := dc.convert(dstFieldValue, srcFieldValue); ok || err != nil {
+		return ok, err
+	}
+
 	if srcFieldType.AssignableTo(dstFieldType) {
 		dstFieldValue.Set(srcFieldValue)
-		return true
+		return true, nil
 	}
 
 	if _, ok := tagOptions[optionConvert]; ok && srcFieldType.ConvertibleTo(dstFieldType) {
 		dstFieldValue.Set(srcFieldValue.Convert(dstFieldType))
-		return true
+		return true, nil
 	}
 
-	return false
+	return false, nil
 }
 
-func processNullableTypeValuer2Ptr(srcFieldVal, dstFieldVal reflect.Value,
+func (dc *CopyStruct) processNullableTypeValuer2Ptr(srcFieldVal, dstFieldVal reflect.Value,
 	dstStructField reflect.StructField, tagOptions tagOptions,
-) {
+) (bool, error) {
 	// We have same nullable type on both sides
-	if setFieldValue(srcFieldVal.Type(), dstStructField.Type, dstFieldVal, srcFieldVal, tagOptions) {
-		return
+	ok, err := dc.setFieldValue(srcFieldVal.Type(), dstStructField.Type, dstFieldVal, srcFieldVal, tagOptions)
+	if ok || err != nil {
+		return ok, err
 	}
 
 	v, _ := srcFieldVal.Interface().(driver.Valuer).Value()
 	if v == nil {
-		return
+		return true, nil
 	}
 
 	valueType := reflect.TypeOf(v)
@@ -234,35 +300,36 @@ func processNullableTypeValuer2Ptr(srcFieldVal, dstFieldVal reflect.Value,
 	ptr := reflect.New(valueType)
 	ptr.Elem().Set(reflect.ValueOf(v))
 
-	setFieldValue(valueType, dstStructField.Type.Elem(), dstFieldVal, ptr, tagOptions)
+	return dc.setFieldValue(valueType, dstStructField.Type.Elem(), dstFieldVal, ptr, tagOptions)
 }
 
-func processNullableTypeValuer2Value(srcFieldValue, dstFieldValue reflect.Value,
+func (dc *CopyStruct) processNullableTypeValuer2Value(srcFieldValue, dstFieldValue reflect.Value,
 	dstFieldType reflect.StructField, force bool, tagOptions tagOptions,
-) {
+) (bool, error) {
 	// We have same nullable type on both sides
-	if setFieldValue(srcFieldValue.Type(), dstFieldType.Type, dstFieldValue, srcFieldValue, tagOptions) {
-		return
+	ok, err := dc.setFieldValue(srcFieldValue.Type(), dstFieldType.Type, dstFieldValue, srcFieldValue, tagOptions)
+	if ok || err != nil {
+		return ok, err
 	}
 
 	if !force {
-		return
+		return false, nil
 	}
 
 	v, _ := srcFieldValue.Interface().(driver.Valuer).Value()
 	if v == nil {
-		return
+		return true, nil
 	}
 
 	rv := reflect.ValueOf(v)
 
-	setFieldValue(rv.Type(), dstFieldType.Type, dstFieldValue, rv, tagOptions)
+	return dc.setFieldValue(rv.Type(), dstFieldType.Type, dstFieldValue, rv, tagOptions)
 }
 
-func (dc *CopyStruct) parseDstFieldName(srcFieldName string, reversed bool,
-	srcStructField reflect.StructField, dst interface{},
+func (dc *CopyStruct) parseDstFieldName(srcStructField reflect.StructField, reversed bool,
+	dst reflect.Type,
 ) (string, tagOptions) {
-	dstFieldName := srcFieldName
+	dstFieldName := srcStructField.Name
 
 	if reversed {
 		tagOptions := parseTagOptions(srcStructField.Tag.Get(dc.tagName))
@@ -273,7 +340,7 @@ func (dc *CopyStruct) parseDstFieldName(srcFieldName string, reversed bool,
 		return dstFieldName, tagOptions
 	}
 
-	if name, opts := dc.getRelatedField(dst, srcFieldName); name != "" {
+	if name, opts := dc.getRelatedField(dst, dstFieldName); name != "" {
 		return name, opts
 	}
 
@@ -299,19 +366,14 @@ func parseTagOptions(value string) tagOptions {
 	return options
 }
 
-// getRelatedField returns first matching field.
-func (dc *CopyStruct) getRelatedField(instance interface{}, name string) (string, tagOptions) {
-	value := reflect.Indirect(reflect.ValueOf(instance))
-	fieldName := ""
-	tagOptions := tagOptions{}
-
-	for i := 0; i < value.NumField(); i++ {
-		vField := value.Field(i)
-		tField := value.Type().Field(i)
+// getRelatedField returns first matching field of the struct type t.
+func (dc *CopyStruct) getRelatedField(t reflect.Type, name string) (string, tagOptions) {
+	for i := 0; i < t.NumField(); i++ {
+		tField := t.Field(i)
 		tagOptions := parseTagOptions(tField.Tag.Get(dc.tagName))
 
 		if tField.Type.Kind() == reflect.Struct && tField.Anonymous {
-			if n, o := dc.getRelatedField(vField.Interface(), name); n != "" {
+			if n, o := dc.getRelatedField(tField.Type, name); n != "" {
 				return n, o
 			}
 		}
@@ -325,35 +387,19 @@ func (dc *CopyStruct) getRelatedField(instance interface{}, name string) (string
 		}
 	}
 
-	return fieldName, tagOptions
+	return "", tagOptions{}
 }
 
-// getMethodNames returns instance's method names.
-func getMethodNames(instance interface{}) []string {
-	t := reflect.TypeOf(instance)
-	methods := make([]string, t.NumMethod())
-
-	for i := 0; i < t.NumMethod(); i++ {
-		methods[i] = t.Method(i).Name
-	}
-
-	return methods
-}
-
-// getFieldNames returns instance's field names.
-func getFieldNames(instance interface{}) []string {
-	v := reflect.Indirect(reflect.ValueOf(instance))
-	t := v.Type()
-
+// getFieldNames returns field names of the struct type t, fields of embedded structs are flattened.
+func getFieldNames(t reflect.Type) []string {
 	if t.Kind() != reflect.Struct {
 		return nil
 	}
 
-	fields := make([]string, 0, v.NumField())
+	fields := make([]string, 0, t.NumField())
 
-	for i := 0; i < v.NumField(); i++ {
-		vField := v.Field(i)
-		tField := v.Type().Field(i)
+	for i := 0; i < t.NumField(); i++ {
+		tField := t.Field(i)
 
 		// Is exportable?
 		if tField.PkgPath != "" {
@@ -361,7 +407,7 @@ func getFieldNames(instance interface{}) []string {
 		}
 
 		if tField.Type.Kind() == reflect.Struct && tField.Anonymous {
-			fields = append(fields, getFieldNames(vField.Interface())...)
+			fields = append(fields, getFieldNames(tField.Type)...)
 		} else {
 			fields = append(fields, tField.Name)
 		}
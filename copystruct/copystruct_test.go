@@ -388,6 +388,19 @@ func TestField_EmptyInterface(t *testing.T) {
 	dstForce = &DstForce{}
 	assert.Nil(t, copystruct.Copy(dstForce).From(srcForce))
 	assert.Equal(t, srcForce.Rel, dstForce.Rel)
+
+	// nullable types are copied to interfaces as they are, unlike to maps of interfaces
+	type SrcNull struct {
+		N sql.NullString
+	}
+
+	type DstNull struct {
+		N interface{} `copystruct:"force"`
+	}
+
+	dstNull := &DstNull{}
+	assert.Nil(t, copystruct.Copy(&SrcNull{N: sql.NullString{String: "a", Valid: true}}).To(dstNull))
+	assert.Equal(t, sql.NullString{String: "a", Valid: true}, dstNull.N)
 }
 
 func TestField_NullTypes(t *testing.T) {
@@ -0,0 +1,145 @@
+package copystruct
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// visit is a pointer of the source which is already mapped to a value of the destination type.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// copyValue sets src to dst, values of different types are mapped recursively:
+// nested structs, pointers to structs, slices and maps with elements of different types.
+// It returns false if the types could not be mapped.
+func (dc *CopyStruct) copyValue(dst, src reflect.Value, tagOptions tagOptions, reversed bool) (bool, error) {
+	if ok, err := dc.setFieldValue(src.Type(), dst.Type(), dst, src, tagOptions); ok || err != nil {
+		return ok, err
+	}
+
+	if !dc.mappable(dst.Type(), src.Type(), tagOptions, map[[2]reflect.Type]bool{}) {
+		return false, nil
+	}
+
+	return true, dc.deepCopy(dst, src, tagOptions, reversed)
+}
+
+// mappable checks if a value of the src type could be copied to a value of the dst type.
+// seen contains type pairs on the way, which are considered mappable to stop recursive types.
+func (dc *CopyStruct) mappable(dst, src reflect.Type, tagOptions tagOptions, seen map[[2]reflect.Type]bool) bool {
+	if src.AssignableTo(dst) || dc.hasConverter(dst, src) {
+		return true
+	}
+
+	if _, ok := tagOptions[optionConvert]; ok && src.ConvertibleTo(dst) {
+		return true
+	}
+
+	pair := [2]reflect.Type{dst, src}
+	if seen[pair] {
+		return true
+	}
+
+	seen[pair] = true
+
+	switch {
+	case src.Kind() == reflect.Ptr:
+		return dc.mappable(dst, src.Elem(), tagOptions, seen)
+	case dst.Kind() == reflect.Ptr:
+		return dc.mappable(dst.Elem(), src, tagOptions, seen)
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return !isNullableType(src)
+	case (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && dst.Kind() == reflect.Slice:
+		return dc.mappable(dst.Elem(), src.Elem(), tagOptions, seen)
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Map:
+		return dc.mappable(dst.Key(), src.Key(), tagOptions, seen) && dc.mappable(dst.Elem(), src.Elem(), tagOptions, seen)
+	}
+
+	return false
+}
+
+// deepCopy maps src to dst of a different type, the types are checked by mappable.
+// Pointers of the source are mapped once, so self-referencing graphs keep their shape.
+func (dc *CopyStruct) deepCopy(dst, src reflect.Value, tagOptions tagOptions, reversed bool) error {
+	switch {
+	case src.Kind() == reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+
+		if dst.Kind() != reflect.Ptr {
+			_, err := dc.copyValue(dst, src.Elem(), tagOptions, reversed)
+			return err
+		}
+
+		key := visit{ptr: src.Pointer(), typ: dst.Type()}
+		if mapped, ok := dc.visited[key]; ok {
+			dst.Set(mapped)
+			return nil
+		}
+
+		ptr := reflect.New(dst.Type().Elem())
+		dc.visited[key] = ptr
+		dst.Set(ptr)
+
+		_, err := dc.copyValue(ptr.Elem(), src.Elem(), tagOptions, reversed)
+
+		return err
+	case dst.Kind() == reflect.Ptr:
+		ptr := reflect.New(dst.Type().Elem())
+		dst.Set(ptr)
+
+		_, err := dc.copyValue(ptr.Elem(), src, tagOptions, reversed)
+
+		return err
+	case src.Kind() == reflect.Struct:
+		return dc.copyStruct(dst, src, reversed)
+	case src.Kind() == reflect.Slice || src.Kind() == reflect.Array:
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			return nil
+		}
+
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			dc.enterIndex(i)
+			_, err := dc.copyValue(slice.Index(i), src.Index(i), tagOptions, reversed)
+			dc.leave()
+
+			if err != nil {
+				return withField(err, fmt.Sprintf("[%d]", i))
+			}
+		}
+
+		dst.Set(slice)
+	case src.Kind() == reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+
+		m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		for _, k := range src.MapKeys() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if _, err := dc.copyValue(key, k, tagOptions, reversed); err != nil {
+				return withField(err, fmt.Sprintf("[%v]", k))
+			}
+
+			elem := reflect.New(dst.Type().Elem()).Elem()
+
+			dc.enterIndex(k)
+			_, err := dc.copyValue(elem, src.MapIndex(k), tagOptions, reversed)
+			dc.leave()
+
+			if err != nil {
+				return withField(err, fmt.Sprintf("[%v]", k))
+			}
+
+			m.SetMapIndex(key, elem)
+		}
+
+		dst.Set(m)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package copystruct_test
+
+import (
+	"testing"
+
+	"github.com/bingoohuang/gor/copystruct"
+	assert "github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type AddressDTO struct {
+	Town   string `copystruct:"field:City"`
+	Street string
+}
+
+type Entity struct {
+	Name     string
+	Address  Address
+	Billing  *Address
+	Shipping Address
+	Items    []Address
+	ByKind   map[string]Address
+	Codes    []UserName
+}
+
+type EntityDTO struct {
+	Name     string
+	Address  AddressDTO
+	Billing  *AddressDTO
+	Shipping *AddressDTO
+	Items    []AddressDTO
+	ByKind   map[string]*AddressDTO
+	Codes    []string `copystruct:"convert"`
+}
+
+func TestDeepCopy(t *testing.T) {
+	src := &Entity{
+		Name:     "bingoo",
+		Address:  Address{City: "Beijing", Street: "Main"},
+		Billing:  &Address{City: "Shanghai"},
+		Shipping: Address{City: "Nanjing"},
+		Items:    []Address{{City: "A"}, {City: "B"}},
+		ByKind:   map[string]Address{"home": {City: "Xian"}},
+		Codes:    []UserName{"x", "y"},
+	}
+
+	//
+	// To()
+	//
+
+	dst := &EntityDTO{}
+	assert.Nil(t, copystruct.Copy(src).To(dst))
+	assert.Equal(t, EntityDTO{
+		Name:     "bingoo",
+		Address:  AddressDTO{Town: "Beijing", Street: "Main"},
+		Billing:  &AddressDTO{Town: "Shanghai"},
+		Shipping: &AddressDTO{Town: "Nanjing"},
+		Items:    []AddressDTO{{Town: "A"}, {Town: "B"}},
+		ByKind:   map[string]*AddressDTO{"home": {Town: "Xian"}},
+		Codes:    []string{"x", "y"},
+	}, *dst)
+
+	//
+	// From()
+	//
+
+	back := &Entity{}
+	assert.Nil(t, copystruct.Copy(back).From(dst))
+	assert.Equal(t, src.Address, back.Address)
+	assert.Equal(t, src.Billing, back.Billing)
+	assert.Equal(t, src.Shipping, back.Shipping)
+	assert.Equal(t, src.Items, back.Items)
+	assert.Equal(t, src.ByKind, back.ByKind)
+	assert.Equal(t, src.Codes, back.Codes)
+}
+
+func TestDeepCopy_Nil(t *testing.T) {
+	dst := &EntityDTO{}
+	assert.Nil(t, copystruct.Copy(&Entity{}).To(dst))
+	assert.Nil(t, dst.Billing)
+	assert.Nil(t, dst.Items)
+	assert.Nil(t, dst.ByKind)
+	assert.NotNil(t, dst.Shipping, "a value is mapped to a new pointer")
+}
+
+type Node struct {
+	Name     string
+	Parent   *Node
+	Children []*Node
+}
+
+type NodeDTO struct {
+	Name     string
+	Parent   *NodeDTO
+	Children []*NodeDTO
+}
+
+func TestDeepCopy_Cycle(t *testing.T) {
+	root := &Node{Name: "root"}
+	child := &Node{Name: "child", Parent: root}
+	root.Children = []*Node{child, child}
+	root.Parent = root
+
+	dst := &NodeDTO{}
+	assert.Nil(t, copystruct.Copy(root).To(dst))
+	assert.Equal(t, "root", dst.Name)
+	assert.True(t, dst.Parent == dst)
+	assert.Len(t, dst.Children, 2)
+	assert.Equal(t, "child", dst.Children[0].Name)
+	assert.True(t, dst.Children[0] == dst.Children[1], "a shared pointer is mapped once")
+	assert.True(t, dst.Children[0].Parent == dst)
+}
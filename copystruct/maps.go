@@ -0,0 +1,278 @@
+package copystruct
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// keyPlan is a compiled mapping of a struct field to a key of a map.
+type keyPlan struct {
+	field reflect.StructField
+	// key is the field option or the name of the field, path is the key split by dots for nested maps.
+	key     string
+	path    []string
+	options tagOptions
+}
+
+// isStringMap checks if t is a map with string keys like map[string]interface{}.
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isNumber checks if k is a kind of integers or floats.
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// getKeyPlans compiles the keys of the fields of the struct type t once per tag name, and caches the result.
+func (dc *CopyStruct) getKeyPlans(t reflect.Type) []keyPlan {
+	key := planKey{src: t, tagName: dc.tagName, keys: true}
+	if plans, ok := planCache.Load(key); ok {
+		return plans.([]keyPlan)
+	}
+
+	plans := make([]keyPlan, 0, t.NumField())
+
+	for _, name := range getFieldNames(t) {
+		f, ok := t.FieldByName(name)
+		if !ok {
+			continue
+		}
+
+		p := keyPlan{field: f, key: name, options: parseTagOptions(f.Tag.Get(dc.tagName))}
+		if v := p.options[optionField]; v != "" {
+			p.key = v
+		}
+
+		p.path = strings.Split(p.key, ".")
+		plans = append(plans, p)
+	}
+
+	actual, _ := planCache.LoadOrStore(key, plans)
+
+	return actual.([]keyPlan)
+}
+
+// structToMap copies the fields of src, which is a struct or a pointer to a struct, to the map dstValue.
+// A dotted key like Profile.Email sets a nested map if the values of the map are interfaces.
+func (dc *CopyStruct) structToMap(dstValue, src reflect.Value) error {
+	srcValue := reflect.Indirect(src)
+	if srcValue.Kind() != reflect.Struct {
+		return fmt.Errorf("source %v should be a struct", src.Type())
+	}
+
+	if dstValue.IsNil() {
+		dstValue.Set(reflect.MakeMap(dstValue.Type()))
+	}
+
+	plans := dc.getKeyPlans(srcValue.Type())
+
+	for i := range plans {
+		p := &plans[i]
+		if _, ok := p.options[optionSkip]; ok {
+			dc.record(p.field.Name, p.key, FieldSkipped, nil)
+			continue
+		}
+
+		elem := reflect.New(dstValue.Type().Elem()).Elem()
+		status, err := dc.copyToMapValue(elem, srcValue.FieldByIndex(p.field.Index), p)
+
+		if dc.record(p.field.Name, p.key, status, err); err != nil {
+			return withField(err, p.key)
+		}
+
+		if status != FieldFailed {
+			setMapKey(dstValue, p.path, elem)
+		}
+	}
+
+	return nil
+}
+
+// copyToMapValue copies a field to a value of a map, nullable types are copied like fields of structs,
+// except that a map of interfaces takes the driver.Valuer value of a nullable field with the force option.
+func (dc *CopyStruct) copyToMapValue(elem, fieldValue reflect.Value, p *keyPlan) (FieldStatus, error) {
+	if isNullableType(p.field.Type) {
+		_, force := p.options[optionForce]
+		if force && elem.Kind() == reflect.Interface {
+			if v, _ := fieldValue.Interface().(driver.Valuer).Value(); v != nil {
+				elem.Set(reflect.ValueOf(v))
+			}
+
+			return FieldConverted, nil
+		}
+
+		ok, err := dc.processNullableTypeValuer2Value(fieldValue, elem, reflect.StructField{Type: elem.Type()},
+			force, p.options)
+
+		return fieldStatus(ok && err == nil, elem.Type(), p.field.Type), err
+	}
+
+	dc.enter(p.field.Name, p.key)
+	ok, err := dc.copyValue(elem, fieldValue, p.options, false)
+	dc.leave()
+
+	return fieldStatus(ok && err == nil, elem.Type(), p.field.Type), err
+}
+
+// setMapKey sets the value v by the path of keys. Nested maps of the same type are created
+// if the map could contain them, otherwise the dotted key is set as it is.
+func setMapKey(m reflect.Value, path []string, v reflect.Value) {
+	if len(path) > 1 && m.Type().AssignableTo(m.Type().Elem()) {
+		for _, k := range path[:len(path)-1] {
+			next := m.MapIndex(mapKey(m, k))
+			if next.IsValid() && next.Kind() == reflect.Interface {
+				next = next.Elem()
+			}
+
+			if !next.IsValid() || next.Type() != m.Type() {
+				next = reflect.MakeMap(m.Type())
+				m.SetMapIndex(mapKey(m, k), next)
+			}
+
+			m = next
+		}
+
+		path = path[len(path)-1:]
+	}
+
+	m.SetMapIndex(mapKey(m, strings.Join(path, ".")), v)
+}
+
+// mapKey returns the key of the map m with string keys.
+func mapKey(m reflect.Value, key string) reflect.Value {
+	return reflect.ValueOf(key).Convert(m.Type().Key())
+}
+
+// lookupMapKey finds the value of the key, or the value by the path of the dotted key in nested maps.
+func lookupMapKey(m reflect.Value, key string, path []string) (reflect.Value, bool) {
+	if v := m.MapIndex(mapKey(m, key)); v.IsValid() {
+		return v, true
+	}
+
+	if len(path) == 1 {
+		return reflect.Value{}, false
+	}
+
+	for _, k := range path {
+		if m.Kind() == reflect.Interface {
+			m = m.Elem()
+		}
+
+		if !m.IsValid() || !isStringMap(m.Type()) {
+			return reflect.Value{}, false
+		}
+
+		if m = m.MapIndex(mapKey(m, k)); !m.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+
+	return m, true
+}
+
+// mapToStruct copies the values of the map srcValue to the fields of the struct dstValue.
+// Keys of the map which are not used by any field are reported as unmapped,
+// fields without values in the map are reported as unpopulated.
+func (dc *CopyStruct) mapToStruct(dstValue, srcValue reflect.Value) error {
+	if dstValue.Kind() != reflect.Struct {
+		return fmt.Errorf("destination %v should be a struct", dstValue.Type())
+	}
+
+	plans := dc.getKeyPlans(dstValue.Type())
+	used := make(map[string]bool)
+
+	for i := range plans {
+		p := &plans[i]
+		used[p.key], used[p.path[0]] = true, true
+
+		if _, ok := p.options[optionSkip]; ok {
+			dc.record(p.key, p.field.Name, FieldSkipped, nil)
+			continue
+		}
+
+		v, found := lookupMapKey(srcValue, p.key, p.path)
+		if !found {
+			dc.record("", p.field.Name, FieldUnpopulated, nil)
+			continue
+		}
+
+		dc.enter(p.key, p.field.Name)
+		status, err := dc.copyMapValue(dstValue.FieldByIndex(p.field.Index), v, p.options)
+		dc.leave()
+
+		if dc.record(p.key, p.field.Name, status, err); err != nil {
+			return withField(err, p.field.Name)
+		}
+	}
+
+	if dc.report != nil {
+		keys := make([]string, 0, srcValue.Len())
+
+		for _, k := range srcValue.MapKeys() {
+			if !used[k.String()] {
+				keys = append(keys, k.String())
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			dc.record(k, "", FieldUnmapped, nil)
+		}
+	}
+
+	return nil
+}
+
+// copyMapValue copies a value of a map to the field dst. Maps are copied to nested structs recursively,
+// values are scanned by fields implementing sql.Scanner, numbers like float64 decoded from JSON
+// are converted to the number type of the field.
+func (dc *CopyStruct) copyMapValue(dst, v reflect.Value, options tagOptions) (FieldStatus, error) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return FieldCopied, nil
+	}
+
+	structType := dst.Type()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if isStringMap(v.Type()) && structType.Kind() == reflect.Struct {
+		if dst.Kind() == reflect.Ptr {
+			dst.Set(reflect.New(structType))
+			dst = dst.Elem()
+		}
+
+		return FieldConverted, dc.mapToStruct(dst, v)
+	}
+
+	ok, err := dc.copyValue(dst, v, options, false)
+	if ok || err != nil {
+		return fieldStatus(ok && err == nil, dst.Type(), v.Type()), err
+	}
+
+	if scanner, ok := dst.Addr().Interface().(sql.Scanner); ok {
+		if err := scanner.Scan(v.Interface()); err != nil {
+			return FieldFailed, err
+		}
+
+		return FieldConverted, nil
+	}
+
+	if isNumber(v.Kind()) && isNumber(dst.Kind()) {
+		dst.Set(v.Convert(dst.Type()))
+		return FieldConverted, nil
+	}
+
+	return FieldFailed, nil
+}
@@ -0,0 +1,94 @@
+package copystruct_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/bingoohuang/gor/copystruct"
+	assert "github.com/stretchr/testify/require"
+)
+
+type Member struct {
+	Name     string `copystruct:"field:name"`
+	Age      int    `copystruct:"field:age"`
+	Email    sql.NullString
+	Nickname sql.NullString `copystruct:"field:nickname;force"`
+	City     string         `copystruct:"field:address.city"`
+	Password string         `copystruct:"skip"`
+	Address  *Address
+	Tags     []string
+}
+
+func TestMap_StructToMap(t *testing.T) {
+	src := &Member{
+		Name:     "bingoo",
+		Age:      18,
+		Email:    sql.NullString{String: "bingoo@example.com", Valid: true},
+		City:     "Beijing",
+		Password: "secret",
+		Tags:     []string{"go"},
+	}
+
+	m := map[string]interface{}{"address": map[string]interface{}{"zip": "100000"}}
+	assert.Nil(t, copystruct.Copy(src).To(m))
+	assert.Equal(t, map[string]interface{}{
+		"name":     "bingoo",
+		"age":      18,
+		"Email":    src.Email,
+		"nickname": nil,
+		"address":  map[string]interface{}{"zip": "100000", "city": "Beijing"},
+		"Address":  (*Address)(nil),
+		"Tags":     []string{"go"},
+	}, m)
+
+	var back map[string]interface{}
+	assert.Nil(t, copystruct.Copy(&back).From(src))
+	assert.Equal(t, m["address"].(map[string]interface{})["city"], back["address"].(map[string]interface{})["city"])
+
+	var flat map[string]string
+	assert.Nil(t, copystruct.Copy(src).To(&flat))
+	assert.Equal(t, map[string]string{"name": "bingoo", "nickname": "", "address.city": "Beijing"}, flat,
+		"dotted keys are kept in maps of non-interface values, values of other types are not copied")
+}
+
+func TestMap_MapToStruct(t *testing.T) {
+	var m map[string]interface{}
+
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"name": "bingoo", "age": 18, "Email": "bingoo@example.com", "nickname": null,
+		"address": {"city": "Beijing"}, "Password": "secret", "Address": {"City": "Shanghai"},
+		"Unknown": 1
+	}`), &m))
+
+	dst := &Member{Nickname: sql.NullString{String: "old", Valid: true}}
+	c := copystruct.Copy(dst, copystruct.WithReport())
+	assert.Nil(t, c.From(m))
+	assert.Equal(t, Member{
+		Name:    "bingoo",
+		Age:     18,
+		Email:   sql.NullString{String: "bingoo@example.com", Valid: true},
+		City:    "Beijing",
+		Address: &Address{City: "Shanghai"},
+	}, *dst)
+	assert.Equal(t, []copystruct.FieldResult{{Src: "Unknown", Status: copystruct.FieldUnmapped}},
+		c.Report().Filter(copystruct.FieldUnmapped))
+	assert.Equal(t, []copystruct.FieldResult{
+		{Dst: "Address.Street", Status: copystruct.FieldUnpopulated},
+		{Dst: "Tags", Status: copystruct.FieldUnpopulated},
+	}, c.Report().Filter(copystruct.FieldUnpopulated))
+
+	flat := &Member{}
+	assert.Nil(t, copystruct.Copy(map[string]string{"name": "bingoo", "address.city": "Beijing"}).To(flat))
+	assert.Equal(t, Member{Name: "bingoo", City: "Beijing"}, *flat)
+
+	err := copystruct.Copy(map[string]interface{}{"name": "bingoo"}, copystruct.Strict()).To(&Member{})
+	assert.EqualError(t, err, "copy strictly: destination fields not populated: "+
+		"Age, Email, Nickname, City, Address, Tags")
+
+	err = copystruct.Copy(map[string]interface{}{"Address": map[string]interface{}{"City": 1}}).To(&Member{})
+	assert.Nil(t, err, "values of mismatched types are not copied")
+
+	err = copystruct.Copy(map[string]interface{}{"Email": []int{1}}).To(&Member{})
+	assert.EqualError(t, err, "copy field Email: unsupported Scan, storing driver.Value type []int into type *string")
+}
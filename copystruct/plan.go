@@ -0,0 +1,107 @@
+package copystruct
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldPlan is a compiled mapping of a source field to its destination field.
+type fieldPlan struct {
+	src reflect.StructField
+	// dst is the destination field named dstName, dstName is empty if there is no such field.
+	dst     reflect.StructField
+	dstName string
+	options tagOptions
+	// status is FieldSkipped or FieldUnmapped if the field is not copied at all.
+	status FieldStatus
+}
+
+// methodPlan is a compiled mapping of a source method to its related destination field.
+type methodPlan struct {
+	index   int
+	name    string
+	dst     reflect.StructField
+	options tagOptions
+}
+
+// structPlan is a compiled mapping of a source struct to a destination struct.
+type structPlan struct {
+	fields  []fieldPlan
+	methods []methodPlan
+	// dstFields are names of the destination fields to be populated, fields with the skip option excluded.
+	dstFields []string
+}
+
+// planKey is a key of compiled mappings, plans depend on how tags are read.
+type planKey struct {
+	dst, src reflect.Type
+	tagName  string
+	reversed bool
+	// keys is true for the keys of the fields of the struct src, see getKeyPlans.
+	keys bool
+}
+
+// nolint:gochecknoglobals
+var planCache sync.Map // map[planKey]*structPlan or []keyPlan
+
+// getStructPlan compiles the mapping of the src type, a struct or a pointer to a struct, to the dst struct type
+// once per tag name and direction, and caches the result. Fields are looked up by name only here,
+// copyStruct executes the plan by indexes.
+func (dc *CopyStruct) getStructPlan(dst, src reflect.Type, reversed bool) *structPlan {
+	key := planKey{dst: dst, src: src, tagName: dc.tagName, reversed: reversed}
+	if plan, ok := planCache.Load(key); ok {
+		return plan.(*structPlan)
+	}
+
+	plan := &structPlan{}
+	srcStruct := src
+
+	if srcStruct.Kind() == reflect.Ptr {
+		srcStruct = srcStruct.Elem()
+	}
+
+	for _, f := range getFieldNames(srcStruct) {
+		srcField, ok := srcStruct.FieldByName(f)
+		if !ok {
+			continue
+		}
+
+		p := fieldPlan{src: srcField}
+		p.dstName, p.options = dc.parseDstFieldName(srcField, reversed, dst)
+
+		if _, skip := p.options[optionSkip]; skip {
+			p.status = FieldSkipped
+		} else if p.dst, ok = dst.FieldByName(p.dstName); !ok {
+			p.dstName, p.status = "", FieldUnmapped
+		}
+
+		plan.fields = append(plan.fields, p)
+	}
+
+	for i := 0; i < src.NumMethod(); i++ {
+		m := src.Method(i).Name
+
+		name, options := dc.getRelatedField(dst, m)
+		if name == "" {
+			continue
+		}
+
+		dstField, ok := dst.FieldByName(name)
+		if !ok {
+			continue
+		}
+
+		plan.methods = append(plan.methods, methodPlan{index: i, name: m, dst: dstField, options: options})
+	}
+
+	for _, name := range getFieldNames(dst) {
+		f, _ := dst.FieldByName(name)
+		if _, skip := parseTagOptions(f.Tag.Get(dc.tagName))[optionSkip]; !skip {
+			plan.dstFields = append(plan.dstFields, name)
+		}
+	}
+
+	actual, _ := planCache.LoadOrStore(key, plan)
+
+	return actual.(*structPlan)
+}
@@ -0,0 +1,128 @@
+package copystruct
+
+import (
+	"database/sql"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type benchmarkLine struct {
+	SKU      string
+	Quantity int
+	Price    float64
+	Note     sql.NullString
+}
+
+type benchmarkLineDTO struct {
+	Code     string `copystruct:"field:SKU"`
+	Quantity int
+	Price    float64
+	Note     string `copystruct:"force"`
+}
+
+type benchmarkOrder struct {
+	ID       int64
+	Customer string
+	Email    string
+	Lines    []benchmarkLine
+}
+
+type benchmarkOrderDTO struct {
+	ID       int64
+	Customer string
+	Email    string
+	Lines    []benchmarkLineDTO
+	Internal string `copystruct:"skip"`
+}
+
+func newBenchmarkOrder() *benchmarkOrder {
+	lines := make([]benchmarkLine, 100) // nolint:gomnd
+	for i := range lines {
+		lines[i] = benchmarkLine{SKU: "sku", Quantity: i, Price: 9.9, Note: sql.NullString{String: "note", Valid: true}}
+	}
+
+	return &benchmarkOrder{ID: 1, Customer: "bingoo", Email: "bingoo@example.com", Lines: lines}
+}
+
+func TestStructPlanCache(t *testing.T) {
+	planCache = sync.Map{}
+
+	dc := Copy(nil)
+	dst, src := reflect.TypeOf(benchmarkOrderDTO{}), reflect.TypeOf(&benchmarkOrder{})
+
+	plan := dc.getStructPlan(dst, src, false)
+	if plan != dc.getStructPlan(dst, src, false) {
+		t.Errorf("plan is not cached")
+	}
+
+	if plan == dc.getStructPlan(dst, src, true) || plan == Copy(nil, TagName("x")).getStructPlan(dst, src, false) {
+		t.Errorf("plan is shared by a different direction or tag name")
+	}
+
+	if len(plan.fields) != 4 || plan.fields[3].dst.Name != "Lines" {
+		t.Errorf("unexpected fields %+v", plan.fields)
+	}
+
+	if !reflect.DeepEqual(plan.dstFields, []string{"ID", "Customer", "Email", "Lines"}) {
+		t.Errorf("unexpected destination fields %v", plan.dstFields)
+	}
+
+	order := newBenchmarkOrder()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			dto := &benchmarkOrderDTO{}
+			if err := Copy(order).To(dto); err != nil || dto.Lines[99].Code != "sku" || dto.Lines[99].Note != "note" {
+				t.Errorf("copy concurrently %+v, %v", dto.Lines[99], err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkCopyCold(b *testing.B) {
+	line := &newBenchmarkOrder().Lines[0]
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		// fields are looked up by names for every copy without cached plans
+		planCache = sync.Map{}
+
+		if err := Copy(line).To(&benchmarkLineDTO{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCopyWarm(b *testing.B) {
+	line := &newBenchmarkOrder().Lines[0]
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := Copy(line).To(&benchmarkLineDTO{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCopyRows(b *testing.B) {
+	order := newBenchmarkOrder()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := Copy(order).To(&benchmarkOrderDTO{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
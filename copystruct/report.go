@@ -0,0 +1,224 @@
+package copystruct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldStatus describes what happened to a field when copying.
+type FieldStatus string
+
+const (
+	// FieldCopied means the value is assigned to the destination field as it is.
+	FieldCopied FieldStatus = "copied"
+	// FieldConverted means the value is converted, by a converter, the convert option,
+	// a driver.Valuer or mapping recursively.
+	FieldConverted = "converted"
+	// FieldSkipped means the field is skipped by the skip option, or it is an interface without the force option.
+	FieldSkipped = "skipped"
+	// FieldFailed means the value could not be copied because the types do not match or a converter fails.
+	FieldFailed = "failed"
+	// FieldUnmapped means the source field has no destination field.
+	FieldUnmapped = "unmapped"
+	// FieldUnpopulated means the destination field is not populated by any source field or method.
+	FieldUnpopulated = "unpopulated"
+)
+
+// FieldResult is the result of copying a field.
+type FieldResult struct {
+	// Src is the path of the source field or method like Items[0].City, empty for an unpopulated field.
+	Src string
+	// Dst is the path of the destination field like Items[0].Town, empty for an unmapped field.
+	Dst    string
+	Status FieldStatus
+	// Err is the error of a converter for a failed field.
+	Err error
+}
+
+// Report describes what To or From did to every field, see WithReport.
+type Report struct {
+	Fields []FieldResult
+}
+
+// Filter returns the results of the fields with the status.
+func (r *Report) Filter(status FieldStatus) []FieldResult {
+	var fields []FieldResult
+
+	for _, f := range r.Fields {
+		if f.Status == status {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// StrictError is returned by To or From in strict mode when some fields are not copied.
+type StrictError struct {
+	// Unmapped contains paths of the source fields without destination fields.
+	Unmapped []string
+	// Unpopulated contains paths of the destination fields which are not populated.
+	Unpopulated []string
+}
+
+// Error returns the error message.
+func (e *StrictError) Error() string {
+	var parts []string
+
+	if len(e.Unmapped) > 0 {
+		parts = append(parts, "source fields without destination: "+strings.Join(e.Unmapped, ", "))
+	}
+
+	if len(e.Unpopulated) > 0 {
+		parts = append(parts, "destination fields not populated: "+strings.Join(e.Unpopulated, ", "))
+	}
+
+	return "copy strictly: " + strings.Join(parts, "; ")
+}
+
+// strictError returns a StrictError listing unmapped, unpopulated and failed fields, or nil.
+func (r *Report) strictError() error {
+	e := &StrictError{}
+
+	for _, f := range r.Fields {
+		switch f.Status {
+		case FieldUnmapped:
+			e.Unmapped = append(e.Unmapped, f.Src)
+		case FieldUnpopulated, FieldFailed:
+			e.Unpopulated = append(e.Unpopulated, f.Dst)
+		}
+	}
+
+	if len(e.Unmapped) == 0 && len(e.Unpopulated) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Strict makes To or From return a StrictError when a source field has no destination field,
+// or a destination field is not populated. Fields with the skip option are not reported.
+func Strict() OptionFn {
+	return func(cs *CopyStruct) {
+		cs.strict = true
+	}
+}
+
+// WithReport collects a Report of the fields when copying, see CopyStruct.Report.
+func WithReport() OptionFn {
+	return func(cs *CopyStruct) {
+		cs.reporting = true
+	}
+}
+
+// Report returns the Report of the last To or From, it is nil without WithReport or Strict options.
+func (dc *CopyStruct) Report() *Report {
+	return dc.report
+}
+
+// fieldStatus returns the status of setting a value of the src type to the dst type.
+func fieldStatus(ok bool, dst, src reflect.Type) FieldStatus {
+	switch {
+	case !ok:
+		return FieldFailed
+	case src.AssignableTo(dst):
+		return FieldCopied
+	default:
+		return FieldConverted
+	}
+}
+
+// enter pushes names of the source and destination fields to the paths for reporting.
+func (dc *CopyStruct) enter(src, dst string) {
+	if dc.report != nil {
+		dc.srcPath = append(dc.srcPath, src)
+		dc.dstPath = append(dc.dstPath, dst)
+	}
+}
+
+// enterIndex pushes an index of a slice or a key of a map like [0] to the paths for reporting.
+func (dc *CopyStruct) enterIndex(index interface{}) {
+	if dc.report != nil {
+		name := fmt.Sprintf("[%v]", index)
+		dc.enter(name, name)
+	}
+}
+
+// leave pops the last names pushed by enter.
+func (dc *CopyStruct) leave() {
+	if dc.report != nil {
+		dc.srcPath = dc.srcPath[:len(dc.srcPath)-1]
+		dc.dstPath = dc.dstPath[:len(dc.dstPath)-1]
+	}
+}
+
+// record adds the result of the field to the report.
+func (dc *CopyStruct) record(src, dst string, status FieldStatus, err error) {
+	if dc.report == nil || status == "" {
+		return
+	}
+
+	dc.report.Fields = append(dc.report.Fields, FieldResult{
+		Src:    joinPath(dc.srcPath, src),
+		Dst:    joinPath(dc.dstPath, dst),
+		Status: status,
+		Err:    err,
+	})
+}
+
+// reported returns the number of the reported fields.
+func (dc *CopyStruct) reported() int {
+	if dc.report == nil {
+		return 0
+	}
+
+	return len(dc.report.Fields)
+}
+
+// recordUnpopulated reports the destination fields, which are not handled by the fields reported
+// since the index from.
+func (dc *CopyStruct) recordUnpopulated(dstFields []string, from int) {
+	if dc.report == nil {
+		return
+	}
+
+	handled := make(map[string]bool)
+
+	for _, f := range dc.report.Fields[from:] {
+		if f.Status != FieldUnmapped {
+			handled[f.Dst] = true
+		}
+	}
+
+	for _, name := range dstFields {
+		if !handled[joinPath(dc.dstPath, name)] {
+			dc.record("", name, FieldUnpopulated, nil)
+		}
+	}
+}
+
+// joinPath joins the parent names and the name like Items[0].City, it returns empty for an empty name.
+func joinPath(parents []string, name string) string {
+	if name == "" {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, p := range parents {
+		writePathName(&b, p)
+	}
+
+	writePathName(&b, name)
+
+	return b.String()
+}
+
+func writePathName(b *strings.Builder, name string) {
+	if b.Len() > 0 && !strings.HasPrefix(name, "[") {
+		b.WriteString(".")
+	}
+
+	b.WriteString(name)
+}
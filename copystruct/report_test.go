@@ -0,0 +1,95 @@
+package copystruct_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/bingoohuang/gor/copystruct"
+	assert "github.com/stretchr/testify/require"
+)
+
+type Profile struct {
+	Name    string
+	Email   sql.NullString
+	Age     int
+	Address Address
+	Items   []Address
+	Secret  string
+	Legacy  int
+}
+
+type ProfileDTO struct {
+	Name     string
+	Email    string `copystruct:"force"`
+	Age      string
+	Address  AddressDTO
+	Items    []AddressDTO
+	Secret   string `copystruct:"skip"`
+	Nickname string
+}
+
+func TestReport(t *testing.T) {
+	src := &Profile{
+		Name:    "bingoo",
+		Email:   sql.NullString{String: "bingoo@example.com", Valid: true},
+		Age:     18,
+		Address: Address{City: "Beijing", Street: "Main"},
+		Items:   []Address{{City: "A"}},
+		Secret:  "secret",
+		Legacy:  1,
+	}
+
+	dst := &ProfileDTO{}
+	c := copystruct.Copy(src, copystruct.WithReport())
+	assert.Nil(t, c.To(dst))
+	assert.Equal(t, []copystruct.FieldResult{
+		{Src: "Name", Dst: "Name", Status: copystruct.FieldCopied},
+		{Src: "Email", Dst: "Email", Status: copystruct.FieldConverted},
+		{Src: "Age", Dst: "Age", Status: copystruct.FieldFailed},
+		{Src: "Address.City", Dst: "Address.Town", Status: copystruct.FieldCopied},
+		{Src: "Address.Street", Dst: "Address.Street", Status: copystruct.FieldCopied},
+		{Src: "Address", Dst: "Address", Status: copystruct.FieldConverted},
+		{Src: "Items[0].City", Dst: "Items[0].Town", Status: copystruct.FieldCopied},
+		{Src: "Items[0].Street", Dst: "Items[0].Street", Status: copystruct.FieldCopied},
+		{Src: "Items", Dst: "Items", Status: copystruct.FieldConverted},
+		{Src: "Secret", Dst: "Secret", Status: copystruct.FieldSkipped},
+		{Src: "Legacy", Status: copystruct.FieldUnmapped},
+		{Dst: "Nickname", Status: copystruct.FieldUnpopulated},
+	}, c.Report().Fields)
+	assert.Equal(t, []copystruct.FieldResult{
+		{Src: "Secret", Dst: "Secret", Status: copystruct.FieldSkipped},
+	}, c.Report().Filter(copystruct.FieldSkipped))
+
+	assert.Nil(t, copystruct.Copy(src).Report(), "a report is collected only by options")
+}
+
+func TestReport_ConverterError(t *testing.T) {
+	failing := copystruct.Converter(func(string) (int, error) { return 0, errors.New("not a number") })
+
+	c := copystruct.Copy(&struct{ Age string }{"x"}, failing, copystruct.WithReport())
+	err := c.To(&struct{ Age int }{})
+	assert.EqualError(t, err, "copy field Age: not a number")
+	assert.Equal(t, []copystruct.FieldResult{
+		{Src: "Age", Dst: "Age", Status: copystruct.FieldFailed, Err: errors.Unwrap(err)},
+	}, c.Report().Fields)
+}
+
+func TestStrict(t *testing.T) {
+	src := &Profile{Items: []Address{{City: "A"}}}
+
+	err := copystruct.Copy(src, copystruct.Strict()).To(&ProfileDTO{})
+	assert.EqualError(t, err, "copy strictly: source fields without destination: Legacy; "+
+		"destination fields not populated: Age, Nickname")
+
+	var se *copystruct.StrictError
+	assert.True(t, errors.As(err, &se))
+	assert.Equal(t, []string{"Legacy"}, se.Unmapped)
+	assert.Equal(t, []string{"Age", "Nickname"}, se.Unpopulated)
+
+	assert.Nil(t, copystruct.Copy(&Address{City: "A"}, copystruct.Strict()).To(&AddressDTO{}))
+
+	back := &Address{}
+	assert.Nil(t, copystruct.Copy(back, copystruct.Strict()).From(&AddressDTO{Town: "A"}))
+	assert.Equal(t, "A", back.City)
+}
@@ -28,6 +28,7 @@ type Adaptor struct {
 	arounderFactories map[string]InvokeArounderFactory
 	arounders         map[reflect.Type]InvokeArounder
 	router            *httprouter.Router
+	validate          ValidateFunc
 }
 
 // NewAdaptor makes a new Adaptor.
@@ -37,6 +38,7 @@ func NewAdaptor() *Adaptor {
 		arounderFactories: make(map[string]InvokeArounderFactory),
 		arounders:         make(map[reflect.Type]InvokeArounder),
 		router:            httprouter.New(),
+		validate:          defaultValidate,
 	}
 
 	a.RegisterTypeProcessor(reflect.TypeOf((*DownloadFile)(nil)).Elem(), downloadFileProcessor)
@@ -230,6 +232,15 @@ func defaultErrorProcessor(g *gin.Context, vs ...interface{}) (interface{}, erro
 		code = sce.GetStateCode()
 	}
 
+	if ve, ok := vs[0].(*ValidateError); ok {
+		v, _ := ve.JSONValue()
+		_ = g.Error(err)
+		g.Abort()
+		Jsonify(g, code, v)
+
+		return nil, nil
+	}
+
 	_ = g.AbortWithError(code, err)
 
 	return nil, nil
@@ -698,6 +709,12 @@ func (a *Adaptor) processStruct(c *gin.Context, arg argIn) (reflect.Value, error
 		return reflect.Value{}, &AdaptorError{Err: err, Context: "ShouldBind"}
 	}
 
+	if a.validate != nil {
+		if err := a.validate(c, argValue.Interface()); err != nil {
+			return reflect.Value{}, validateError(err)
+		}
+	}
+
 	return argValue, nil
 }
 
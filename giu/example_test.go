@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/bingoohuang/gor/giu"
+	"github.com/bingoohuang/gor/validate"
 	"github.com/bingoohuang/gou/lo"
 	"github.com/gin-gonic/gin"
 )
@@ -69,6 +70,26 @@ func ExampleErr() {
 	// {"state":500,"data":"error occurred"}
 }
 
+func ExampleAdaptor_RegisterValidateOption() {
+	router := gin.Default()
+	ga := giu.NewAdaptor()
+	gr := ga.Route(router)
+
+	option, _ := validate.New(validate.FieldNameTag("json"), validate.Locale(validate.LocaleEn))
+	ga.RegisterValidateOption(option)
+
+	type Hello struct {
+		Name string `json:"name" validate:"empty=false"`
+	}
+
+	gr.POST("/hello", func(h Hello) string { return "Welcome " + h.Name })
+
+	rr := PerformRequest("POST", "/hello", router, JSONString(`{"name":""}`))
+	fmt.Println(rr.Code, rr.Body.String())
+	// Output:
+	// 400 {"error":"validation failed","fields":[{"field":"name","message":"name must not be empty"}]}
+}
+
 func ExampleJSON() {
 	router := gin.Default()
 	gr := giu.NewAdaptor().Route(router)
@@ -0,0 +1,102 @@
+package giu
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/bingoohuang/gor/validate"
+)
+
+// ValidateFunc defines the function to validate a bound struct.
+type ValidateFunc func(ctx context.Context, v interface{}) error
+
+func defaultValidate(ctx context.Context, v interface{}) error {
+	return validate.ValidateContext(ctx, v)
+}
+
+// RegisterValidateOption registers the option of validate package to validate bound structs,
+// e.g. created by validate.New(validate.TagName("v"), validate.FieldNameTag("json")).
+// Bound structs are validated with the default option of validate package without it.
+func (a *Adaptor) RegisterValidateOption(option *validate.Option) {
+	a.validate = option.ValidateContext
+}
+
+// RegisterValidateFunc registers the function to validate bound structs, nil disables the validation.
+func (a *Adaptor) RegisterValidateFunc(fn ValidateFunc) {
+	a.validate = fn
+}
+
+// FieldError defines an error of a field of a bound struct.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidateError defines the error of validating a bound struct.
+// It is passed to the registered error processor, the default one responds 400 with field errors.
+// Syntax errors of validate tags are passed as AdaptorError instead, the default processor responds 500.
+type ValidateError struct {
+	Err error
+}
+
+// Error returns the error message.
+func (e *ValidateError) Error() string { return "Validate " + e.Err.Error() }
+
+// Unwrap returns the error of validate package.
+func (e *ValidateError) Unwrap() error { return e.Err }
+
+// GetStateCode returns 400 Bad Request.
+func (e *ValidateError) GetStateCode() int { return http.StatusBadRequest }
+
+// validateErrorBody is the response body of ValidateError, a struct keeps the order of keys.
+type validateErrorBody struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields"`
+}
+
+// validateError wraps an error of validating a bound struct. A syntax error of validate tags is a fault
+// of the server, so it is an AdaptorError and the tags are not listed to the client.
+func validateError(err error) error {
+	var se validate.ErrorSyntax
+	if errors.As(err, &se) {
+		return &AdaptorError{Err: err, Context: "Validate"}
+	}
+
+	return &ValidateError{Err: err}
+}
+
+// JSONValue converts the error to the response body listing field errors.
+func (e *ValidateError) JSONValue() (interface{}, error) {
+	return validateErrorBody{Error: "validation failed", Fields: e.Fields()}, nil
+}
+
+// Fields returns errors of fields, a field is reported by its path, e.g. "items[0].qty".
+// Errors of validate package wrapped by a ValidateFunc are unwrapped.
+func (e *ValidateError) Fields() []FieldError {
+	var errs validate.Errors
+	if !errors.As(e.Err, &errs) {
+		return []FieldError{fieldError(e.Err)}
+	}
+
+	fields := make([]FieldError, len(errs))
+	for i, err := range errs {
+		fields[i] = fieldError(err)
+	}
+
+	return fields
+}
+
+func fieldError(err error) FieldError {
+	var fe validate.ErrorField
+	if !errors.As(err, &fe) {
+		return FieldError{Message: err.Error()}
+	}
+
+	field := fe.FieldPath()
+	if field == "" {
+		field = fe.FieldName()
+	}
+
+	return FieldError{Field: field, Message: fe.Error()}
+}
@@ -0,0 +1,146 @@
+package giu_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bingoohuang/gor/giu"
+	"github.com/bingoohuang/gor/validate"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type CreateOrderReq struct {
+	Name string   `json:"name" validate:"empty=false"`
+	Qty  int      `json:"qty" validate:"gte=1"`
+	Tags []string `json:"tags" validate:"> empty=false"`
+}
+
+type CreateUserReq struct {
+	Email string `json:"email" v:"format=email"`
+}
+
+func TestValidate(t *testing.T) {
+	router := gin.New()
+	ga := giu.NewAdaptor()
+	gr := ga.Route(router)
+
+	gr.POST("/order", func(req CreateOrderReq) string { return "created " + req.Name })
+
+	rr := PerformRequest("POST", "/order", router, JSONString(`{"name":"apple","qty":1}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = PerformRequest("POST", "/order", router, JSONString(`{"name":"apple","qty":0}`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	content, _ := ioutil.ReadAll(rr.Body)
+	assert.Equal(t, `{"error":"validation failed","fields":[{"field":"Qty",`+
+		`"message":"Validation error in field \"Qty\" of type \"int\" using validator \"gte=1\""}]}`,
+		strings.TrimSpace(string(content)))
+
+	option, err := validate.New(validate.TagName("v"), validate.FieldNameTag("json"),
+		validate.CollectAll(), validate.Locale(validate.LocaleEn))
+	assert.Nil(t, err)
+
+	ga.RegisterValidateOption(option)
+	gr.POST("/user", func(req *CreateUserReq) string { return "created " + req.Email })
+
+	rr = PerformRequest("POST", "/user", router, JSONString(`{"email":"bingoo"}`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	content, _ = ioutil.ReadAll(rr.Body)
+	assert.Equal(t, `{"error":"validation failed","fields":[{"field":"email","message":"email must be a valid email address"}]}`,
+		strings.TrimSpace(string(content)))
+
+	rr = PerformRequest("POST", "/order", router, JSONString(`{"name":"apple","qty":0,"tags":[""]}`))
+	assert.Equal(t, http.StatusOK, rr.Code, "validate tag is not used by the registered option")
+
+	ga.RegisterValidateFunc(nil)
+
+	rr = PerformRequest("POST", "/user", router, JSONString(`{"email":"bingoo"}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+type jsonValueError struct{}
+
+func (jsonValueError) Error() string                   { return "json value error" }
+func (jsonValueError) JSONValue() (interface{}, error) { return gin.H{"secret": "x"}, nil }
+
+func TestValidateSyntaxError(t *testing.T) {
+	router := gin.New()
+	ga := giu.NewAdaptor()
+	gr := ga.Route(router)
+
+	type BadReq struct {
+		Name string `json:"name" validate:"unknown=1"`
+	}
+
+	gr.POST("/bad", func(req BadReq) string { return "created " + req.Name })
+
+	rr := PerformRequest("POST", "/bad", router, JSONString(`{"name":"apple"}`))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	content, _ := ioutil.ReadAll(rr.Body)
+	assert.NotContains(t, string(content), "unknown", "validate tags are not listed to the client")
+
+	// errors other than ValidateError are not converted to the response body by the default processor
+	gr.GET("/err", func() error { return jsonValueError{} })
+
+	rr = PerformRequest("GET", "/err", router)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	content, _ = ioutil.ReadAll(rr.Body)
+	assert.Empty(t, strings.TrimSpace(string(content)))
+}
+
+func TestValidateErrProcessor(t *testing.T) {
+	router := gin.New()
+	ga := giu.NewAdaptor()
+	gr := ga.Route(router)
+
+	var fields []giu.FieldError
+
+	ga.RegisterErrProcessor(func(c *gin.Context, vs ...interface{}) {
+		var ve *giu.ValidateError
+		if errors.As(vs[0].(error), &ve) {
+			fields = ve.Fields()
+		}
+
+		c.Status(http.StatusUnprocessableEntity)
+	})
+
+	option, err := validate.New(validate.CollectAll())
+	assert.Nil(t, err)
+
+	ga.RegisterValidateOption(option)
+	gr.POST("/order", func(req CreateOrderReq) string { return "created " + req.Name })
+
+	rr := PerformRequest("POST", "/order", router, JSONString(`{"qty":0,"tags":["a",""]}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Equal(t, []string{"Name", "Qty", "Tags[1]"},
+		[]string{fields[0].Field, fields[1].Field, fields[2].Field})
+
+	ga.RegisterValidateFunc(func(ctx context.Context, v interface{}) error {
+		if err := option.ValidateContext(ctx, v); err != nil {
+			return fmt.Errorf("order: %w", err)
+		}
+
+		return nil
+	})
+
+	rr = PerformRequest("POST", "/order", router, JSONString(`{"qty":0,"tags":["a",""]}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Equal(t, []string{"Name", "Qty", "Tags[1]"},
+		[]string{fields[0].Field, fields[1].Field, fields[2].Field}, "wrapped errors are unwrapped")
+
+	ga.RegisterValidateFunc(func(ctx context.Context, v interface{}) error {
+		return fmt.Errorf("order: %w", validate.Validate(struct {
+			Qty int `validate:"gte=1"`
+		}{}))
+	})
+
+	rr = PerformRequest("POST", "/order", router, JSONString(`{"name":"apple","qty":1}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Equal(t, "Qty", fields[0].Field, "a wrapped field error is unwrapped")
+}
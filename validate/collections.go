@@ -0,0 +1,381 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Following validators check elements of a slice, an array or values of a map as a whole.
+// Nil pointers between elements are skipped.
+// nolint:lll
+const (
+	// Unique checks if elements of a slice or an array are unique.
+	// Elements which are structs could be compared by a field, the field is referenced like in eqfield.
+	// E.g. `validate:"unique"` or `validate:"unique=ID"`
+	Unique Type = "unique"
+
+	// Sorted checks if elements of a slice or an array are sorted in ascending (default) or descending order.
+	// E.g. `validate:"sorted"` or `validate:"sorted=desc"`
+	Sorted = "sorted"
+
+	// DistinctValues checks if values of a map are unique, it accepts a field like unique.
+	// E.g. `validate:"distinct_values"` or `validate:"distinct_values=Email"`
+	DistinctValues = "distinct_values"
+)
+
+// Following orders are available for sorted validator.
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+func validateUnique(value reflect.Value, validator string) ErrorField {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(Unique),
+		comment:    "could not parse or run",
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return errorSyntax
+	}
+
+	segments := make([]string, value.Len())
+	elements := make([]reflect.Value, value.Len())
+
+	for i := range elements {
+		segments[i] = fmt.Sprintf("[%d]", i)
+		elements[i] = value.Index(i)
+	}
+
+	return checkDistinct(value, validator, Unique, segments, elements)
+}
+
+func validateDistinctValues(value reflect.Value, validator string) ErrorField {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(DistinctValues),
+		comment:    "could not parse or run",
+	}
+
+	if value.Kind() != reflect.Map {
+		return errorSyntax
+	}
+
+	keys := value.MapKeys()
+	segments := make([]string, len(keys))
+
+	for i, key := range keys {
+		segments[i] = mapKeySegment(key)
+	}
+
+	// map keys are sorted to report the same duplicate every time
+	sort.Sort(keySegments{segments, keys})
+
+	elements := make([]reflect.Value, len(keys))
+	for i, key := range keys {
+		elements[i] = value.MapIndex(key)
+	}
+
+	return checkDistinct(value, validator, DistinctValues, segments, elements)
+}
+
+// checkDistinct checks if elements (or their fields given by validator) are unique.
+func checkDistinct(value reflect.Value, validator string, validatorType Type,
+	segments []string, elements []reflect.Value) ErrorField {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(validatorType),
+		comment:    "could not parse or run",
+	}
+
+	values := make([]reflect.Value, len(elements))
+
+	for i, element := range elements {
+		v := indirectValue(element)
+		if validator != "" && v.IsValid() {
+			field, err := resolveField([]reflect.Value{v}, validator)
+			if err != nil {
+				return errorSyntax
+			}
+
+			v = field
+		}
+
+		values[i] = v
+	}
+
+	if i, j := findDuplicate(values); j >= 0 {
+		return ErrorValidation{
+			fieldValue:     value,
+			validatorType:  validatorType,
+			validatorValue: validator,
+			err:            fmt.Errorf("%s duplicates %s", segments[j], segments[i]),
+		}
+	}
+
+	return nil
+}
+
+// findDuplicate returns indexes of the first pair of equal values, or -1 if all values are unique.
+// Invalid values are skipped.
+func findDuplicate(values []reflect.Value) (int, int) {
+	seen := make(map[interface{}]int, len(values))
+
+	for j, v := range values {
+		if !v.IsValid() {
+			continue
+		}
+
+		key, ok := distinctKey(v)
+		if !ok {
+			return findDuplicateDeep(values)
+		}
+
+		if i, found := seen[key]; found {
+			return i, j
+		}
+
+		seen[key] = j
+	}
+
+	return -1, -1
+}
+
+// findDuplicateDeep is findDuplicate for values which could not be map keys, e.g. slices.
+func findDuplicateDeep(values []reflect.Value) (int, int) {
+	for j, v := range values {
+		if !v.IsValid() {
+			continue
+		}
+
+		for i := 0; i < j; i++ {
+			if values[i].IsValid() && deepEqual(values[i], v) {
+				return i, j
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+// kindKey is a map key of a value obtained by unexported fields, which could not be converted to an interface.
+type kindKey struct {
+	typ   reflect.Type
+	value interface{}
+}
+
+// distinctKey returns a map key which is equal for equal values.
+func distinctKey(v reflect.Value) (interface{}, bool) {
+	if v.Type() == timeType {
+		// time.Time values with different locations are equal if they are the same instant
+		return timeOf(v).Round(0).UTC(), true
+	}
+
+	if !v.CanInterface() {
+		if key, ok := scalarOf(v); ok {
+			return kindKey{typ: v.Type(), value: key}, true
+		}
+
+		return nil, false
+	}
+
+	if !hashable(v.Type()) {
+		return nil, false
+	}
+
+	return v.Interface(), true
+}
+
+// scalarOf reads a bool, a number or a string by its kind, it works for values obtained by unexported fields.
+func scalarOf(v reflect.Value) (interface{}, bool) {
+	switch {
+	case v.Kind() == reflect.Bool:
+		return v.Bool(), true
+	case isInt(v):
+		return v.Int(), true
+	case isUint(v):
+		return v.Uint(), true
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float(), true
+	case v.Kind() == reflect.Complex64 || v.Kind() == reflect.Complex128:
+		return v.Complex(), true
+	case v.Kind() == reflect.String:
+		return v.String(), true
+	}
+
+	return nil, false
+}
+
+// deepEqual is reflect.DeepEqual for values, values obtained by unexported fields are compared by their kinds.
+// nolint:gocognit
+func deepEqual(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	if a.CanInterface() && b.CanInterface() {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+
+	if x, ok := scalarOf(a); ok {
+		y, _ := scalarOf(b)
+		return x == y
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+
+		return (a.Kind() == reflect.Ptr && a.Pointer() == b.Pointer()) || deepEqual(a.Elem(), b.Elem())
+	case reflect.Slice, reflect.Map:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+
+		if a.Kind() == reflect.Map {
+			for _, key := range a.MapKeys() {
+				if !deepEqual(a.MapIndex(key), b.MapIndex(key)) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !deepEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !deepEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	default:
+		// channels and unsafe pointers
+		return a.Pointer() == b.Pointer()
+	}
+}
+
+// hashable checks if values of the type could be map keys without panics. Comparable types with interfaces,
+// e.g. struct{ X interface{} }, are not hashable if the interfaces hold slices or maps.
+func hashable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return hashable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !hashable(t.Field(i).Type) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return t.Comparable()
+	}
+}
+
+func validateSorted(value reflect.Value, validator string) ErrorField {
+	errorValidation := ErrorValidation{
+		fieldValue:     value,
+		validatorType:  Sorted,
+		validatorValue: validator,
+	}
+
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       Sorted,
+		comment:    "could not parse or run",
+	}
+
+	var accept func(c int) bool
+
+	switch validator {
+	case "", OrderAsc:
+		accept = func(c int) bool { return c <= 0 }
+	case OrderDesc:
+		accept = func(c int) bool { return c >= 0 }
+	default:
+		return errorSyntax
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return errorSyntax
+	}
+
+	prev := -1
+
+	for i := 0; i < value.Len(); i++ {
+		element := indirectValue(value.Index(i))
+		if !element.IsValid() {
+			continue
+		}
+
+		if prev >= 0 {
+			c, ok := compareValues(indirectValue(value.Index(prev)), element)
+			if !ok {
+				return errorSyntax
+			}
+
+			if !accept(c) {
+				errorValidation.err = fmt.Errorf("[%d] is out of order", i)
+				return errorValidation
+			}
+		}
+
+		prev = i
+	}
+
+	return nil
+}
+
+// indirectValue dereferences pointers and interfaces, it returns an invalid value for nil.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// keySegments sorts map keys by their path segments.
+type keySegments struct {
+	segments []string
+	keys     []reflect.Value
+}
+
+func (k keySegments) Len() int           { return len(k.segments) }
+func (k keySegments) Less(i, j int) bool { return k.segments[i] < k.segments[j] }
+func (k keySegments) Swap(i, j int) {
+	k.segments[i], k.segments[j] = k.segments[j], k.segments[i]
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+}
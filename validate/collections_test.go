@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUniqueVal(t *testing.T) {
+	type Item struct {
+		ID   int
+		Name string
+	}
+
+	type St struct {
+		IDs   []int     `validate:"unique"`
+		Names [3]string `validate:"unique"`
+		Items []*Item   `validate:"unique=ID"`
+	}
+
+	if nil != Validate(St{
+		IDs:   []int{1, 2, 3},
+		Names: [3]string{"a", "b", "c"},
+		Items: []*Item{{ID: 1, Name: "a"}, nil, {ID: 2, Name: "a"}},
+	}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	if nil == Validate(St{IDs: []int{1, 2, 1}, Names: [3]string{"a", "b", "c"}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	if nil == Validate(St{Names: [3]string{"a", "b", "a"}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	if nil == Validate(St{Names: [3]string{"a", "b", "c"}, Items: []*Item{{ID: 1}, {ID: 1, Name: "b"}}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		Items []Item `validate:"unique=Missing"`
+	}{Items: []Item{{}}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		Tags [][]string `validate:"unique"`
+	}{Tags: [][]string{{"a"}, {"a"}}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	utc := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if nil == Validate(struct {
+		Times []time.Time `validate:"unique"`
+	}{Times: []time.Time{utc, utc.In(time.FixedZone("UTC+8", 8*60*60))}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	type Any struct{ X interface{} }
+
+	if nil == Validate(struct {
+		Items []Any `validate:"unique"`
+	}{Items: []Any{{X: []int{1}}, {X: []int{1}}}}) {
+		t.Errorf("unique validator does not validate")
+	}
+
+	if err := Validate(struct {
+		Items []Any `validate:"unique"`
+	}{Items: []Any{{X: []int{1}}, {X: []int{2}}, {X: 1}}}); err != nil {
+		t.Errorf("unique validator does not validate: %v", err)
+	}
+}
+
+func TestUniqueValUnexported(t *testing.T) {
+	type Item struct {
+		ID   int
+		Name string
+	}
+
+	utc := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type St struct {
+		ids   []int       `validate:"unique"`
+		items []Item      `validate:"unique"`
+		tags  [][]string  `validate:"unique"`
+		refs  []*Item     `validate:"unique=ID"`
+		times []time.Time `validate:"unique"`
+	}
+
+	if err := Validate(St{
+		ids:   []int{1, 2},
+		items: []Item{{ID: 1}, {ID: 1, Name: "a"}},
+		tags:  [][]string{{"a"}, {"b"}},
+		refs:  []*Item{{ID: 1}, {ID: 2}},
+		times: []time.Time{utc, utc.Add(time.Second)},
+	}); err != nil {
+		t.Errorf("unique validator does not validate unexported fields: %v", err)
+	}
+
+	tests := []St{
+		{ids: []int{1, 2, 1}},
+		{items: []Item{{ID: 1, Name: "a"}, {ID: 1, Name: "a"}}},
+		{tags: [][]string{{"a"}, {"a"}}},
+		{refs: []*Item{{ID: 1}, {ID: 1, Name: "a"}}},
+		{times: []time.Time{utc, utc.In(time.FixedZone("UTC+8", 8*60*60))}},
+	}
+
+	for _, test := range tests {
+		if _, ok := Validate(test).(ErrorValidation); !ok {
+			t.Errorf("unique validator does not validate unexported fields of %+v", test)
+		}
+	}
+}
+
+func TestSortedVal(t *testing.T) {
+	type St struct {
+		Asc  []int    `validate:"sorted"`
+		Desc []string `validate:"sorted=desc"`
+	}
+
+	if nil != Validate(St{Asc: []int{1, 2, 2, 3}, Desc: []string{"c", "b", "a"}}) {
+		t.Errorf("sorted validator does not validate")
+	}
+
+	if nil == Validate(St{Asc: []int{1, 3, 2}}) {
+		t.Errorf("sorted validator does not validate")
+	}
+
+	if nil == Validate(St{Desc: []string{"a", "b"}}) {
+		t.Errorf("sorted validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		Asc []int `validate:"sorted=up"`
+	}{}) {
+		t.Errorf("sorted validator does not validate")
+	}
+}
+
+func TestDistinctValuesVal(t *testing.T) {
+	type User struct {
+		Email string
+	}
+
+	type St struct {
+		Owners map[string]string `validate:"distinct_values"`
+		Users  map[int]User      `validate:"distinct_values=Email"`
+	}
+
+	if nil != Validate(St{
+		Owners: map[string]string{"a": "x", "b": "y"},
+		Users:  map[int]User{1: {Email: "a@b.c"}, 2: {Email: "b@b.c"}},
+	}) {
+		t.Errorf("distinct_values validator does not validate")
+	}
+
+	err := Validate(St{Owners: map[string]string{"a": "x", "b": "y", "c": "x"}})
+	if err == nil {
+		t.Fatalf("distinct_values validator does not validate")
+	}
+
+	expected := `Validation error in field "Owners" of type "map[string]string" using validator "distinct_values": ["c"] duplicates ["a"]`
+	if err.Error() != expected {
+		t.Errorf("distinct_values validator reports %q, expected %q", err.Error(), expected)
+	}
+
+	if nil == Validate(St{Users: map[int]User{1: {Email: "a@b.c"}, 2: {Email: "a@b.c"}}}) {
+		t.Errorf("distinct_values validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		Owners []string `validate:"distinct_values"`
+	}{}) {
+		t.Errorf("distinct_values validator does not validate")
+	}
+
+	type Any struct{ X interface{} }
+
+	if nil == Validate(struct {
+		Values map[string]Any `validate:"distinct_values"`
+	}{Values: map[string]Any{"a": {X: map[string]int{"k": 1}}, "b": {X: map[string]int{"k": 1}}}}) {
+		t.Errorf("distinct_values validator does not validate")
+	}
+
+	type Unexported struct {
+		owners map[string]string   `validate:"distinct_values"`
+		groups map[string][]string `validate:"distinct_values"`
+	}
+
+	if err := Validate(Unexported{
+		owners: map[string]string{"a": "x", "b": "y"},
+		groups: map[string][]string{"a": {"x"}, "b": {"y"}},
+	}); err != nil {
+		t.Errorf("distinct_values validator does not validate unexported fields: %v", err)
+	}
+
+	if _, ok := Validate(Unexported{owners: map[string]string{"a": "x", "b": "x"}}).(ErrorValidation); !ok {
+		t.Errorf("distinct_values validator does not validate unexported fields")
+	}
+
+	if _, ok := Validate(Unexported{groups: map[string][]string{"a": {"x"}, "b": {"x"}}}).(ErrorValidation); !ok {
+		t.Errorf("distinct_values validator does not validate unexported fields")
+	}
+}
@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField defines the range and the names of values of a field of cron expressions.
+type cronField struct {
+	min, max int
+	names    []string // names of values from min, e.g. JAN for 1
+	any      bool     // "?" is allowed
+}
+
+// nolint:gochecknoglobals,gomnd
+var (
+	cronSecond = cronField{min: 0, max: 59}
+	cronFields = []cronField{
+		{min: 0, max: 59},                            // minute
+		{min: 0, max: 23},                            // hour
+		{min: 1, max: 31, any: true},                 // day of month
+		{min: 1, max: 12, names: cronMonths},         // month
+		{min: 0, max: 7, names: cronDays, any: true}, // day of week
+	}
+
+	cronMonths = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronDays   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+	cronMacros = newCodeSet("@yearly @annually @monthly @weekly @daily @midnight @hourly")
+)
+
+// isCron checks if the value is a cron expression with 5 fields, or 6 fields starting with seconds,
+// or a macro like @daily and @every 1h30m.
+func isCron(value string) bool {
+	fields := strings.Fields(value)
+
+	switch {
+	case len(fields) == 1 && cronMacros[fields[0]]:
+		return true
+	case len(fields) == 2 && fields[0] == "@every":
+		d, err := time.ParseDuration(fields[1])
+		return err == nil && d > 0
+	case len(fields) == len(cronFields)+1:
+		if !cronSecond.match(fields[0]) {
+			return false
+		}
+
+		fields = fields[1:]
+	case len(fields) != len(cronFields):
+		return false
+	}
+
+	for i, field := range fields {
+		if !cronFields[i].match(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// match checks if the value is a valid field, which is a comma-separated list of
+// "*", values and ranges like "1-5", all of them may be followed by a step like "/15".
+func (f cronField) match(value string) bool {
+	if value == "?" {
+		return f.any
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		rng := item
+
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			step, err := strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return false
+			}
+
+			rng = item[:i]
+		}
+
+		if rng == "*" {
+			continue
+		}
+
+		bounds := strings.SplitN(rng, "-", 2)
+
+		low, ok := f.value(bounds[0])
+		if !ok {
+			return false
+		}
+
+		if len(bounds) == 2 {
+			high, ok := f.value(bounds[1])
+			if !ok || high < low {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// value parses a number or a name of a value in the field.
+func (f cronField) value(s string) (int, bool) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, true
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, false
+	}
+
+	return n, true
+}
@@ -0,0 +1,340 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// Following validators compare a field with another field.
+// The other field is referenced by its name, e.g. `validate:"gtfield=StartTime"`.
+// A field of a nested struct is referenced by a dotted path, e.g. `validate:"eqfield=Address.Country"`.
+// A field of a parent struct is referenced by leading dots, one ".." per level, e.g. `validate:"eqfield=..Region"`.
+// nolint:lll
+const (
+	// EqField checks if a value equals to the value of another field.
+	// E.g. `validate:"eqfield=Password"`
+	EqField Type = "eqfield"
+
+	// NeField checks if a value does not equal to the value of another field.
+	// E.g. `validate:"nefield=OldPassword"`
+	NeField = "nefield"
+
+	// GtField checks if a number, a string or a time.Time is greater than the value of another field.
+	// E.g. `validate:"gtfield=StartTime"`
+	GtField = "gtfield"
+
+	// GteField checks if a number, a string or a time.Time is greater than or equal to the value of another field.
+	// E.g. `validate:"gtefield=Min"`
+	GteField = "gtefield"
+
+	// LtField checks if a number, a string or a time.Time is less than the value of another field.
+	// E.g. `validate:"ltfield=EndTime"`
+	LtField = "ltfield"
+
+	// LteField checks if a number, a string or a time.Time is less than or equal to the value of another field.
+	// E.g. `validate:"ltefield=Max"`
+	LteField = "ltefield"
+
+	// RequiredIf checks if a value is not zero when another field equals to any of the given elements.
+	// E.g. `validate:"required_if=Kind business,enterprise"`
+	RequiredIf = "required_if"
+
+	// RequiredUnless checks if a value is not zero unless another field equals to any of the given elements.
+	// E.g. `validate:"required_unless=Kind personal"`
+	RequiredUnless = "required_unless"
+)
+
+// crossFieldValidatorFunc is an interface for validator func, which refers to other fields.
+// parents contains the struct of the validated field and all structs above it, the nearest last.
+type crossFieldValidatorFunc func(value reflect.Value, validator string, parents []reflect.Value) ErrorField
+
+// nolint:gochecknoglobals
+var crossFieldValidatorTypeMap = map[Type]crossFieldValidatorFunc{
+	EqField:        compareFieldValidator(EqField, func(c int) bool { return c == 0 }),
+	NeField:        compareFieldValidator(NeField, func(c int) bool { return c != 0 }),
+	GtField:        compareFieldValidator(GtField, func(c int) bool { return c > 0 }),
+	GteField:       compareFieldValidator(GteField, func(c int) bool { return c >= 0 }),
+	LtField:        compareFieldValidator(LtField, func(c int) bool { return c < 0 }),
+	LteField:       compareFieldValidator(LteField, func(c int) bool { return c <= 0 }),
+	RequiredIf:     requiredIfValidator(RequiredIf, true),
+	RequiredUnless: requiredIfValidator(RequiredUnless, false),
+	PostcodeField:  validatePostcodeField,
+
+	OneOfRequired:     groupValidator(OneOfRequired, func(n, _, _ int) bool { return n >= 1 }),
+	AllOrNone:         groupValidator(AllOrNone, func(n, total, _ int) bool { return n == 0 || n == total }),
+	MutuallyExclusive: groupValidator(MutuallyExclusive, func(n, _, _ int) bool { return n <= 1 }),
+	AtLeast:           groupValidator(AtLeast, func(n, _, min int) bool { return n >= min }),
+}
+
+func getCrossFieldValidatorTypeMap() map[Type]crossFieldValidatorFunc {
+	return crossFieldValidatorTypeMap
+}
+
+// compareFieldValidator creates a validator comparing a value with another field.
+func compareFieldValidator(validatorType Type, accept func(int) bool) crossFieldValidatorFunc {
+	return func(value reflect.Value, validator string, parents []reflect.Value) ErrorField {
+		errorValidation := ErrorValidation{
+			fieldValue:     value,
+			validatorType:  validatorType,
+			validatorValue: validator,
+		}
+
+		errorSyntax := ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+
+		other, err := resolveField(parents, validator)
+		if err != nil {
+			return errorSyntax
+		}
+
+		if !other.IsValid() {
+			return errorValidation
+		}
+
+		c, ok := compareValues(value, other)
+		if !ok {
+			if validatorType != EqField && validatorType != NeField {
+				return errorSyntax
+			}
+
+			if !value.CanInterface() || !other.CanInterface() {
+				return errorSyntax
+			}
+
+			if c = 1; reflect.DeepEqual(value.Interface(), other.Interface()) {
+				c = 0
+			}
+		}
+
+		if !accept(c) {
+			return errorValidation
+		}
+
+		return nil
+	}
+}
+
+// requiredIfValidator creates a validator requiring a value to be not zero
+// when another field equals (or does not equal if when is false) to any of the given elements.
+func requiredIfValidator(validatorType Type, when bool) crossFieldValidatorFunc {
+	return func(value reflect.Value, validator string, parents []reflect.Value) ErrorField {
+		errorValidation := ErrorValidation{
+			fieldValue:     value,
+			validatorType:  validatorType,
+			validatorValue: validator,
+		}
+
+		errorSyntax := ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+
+		parts := strings.SplitN(validator, " ", 2)
+		if len(parts) != 2 { // nolint:gomnd
+			return errorSyntax
+		}
+
+		other, err := resolveField(parents, parts[0])
+		if err != nil {
+			return errorSyntax
+		}
+
+		tokens := parseTokens(parts[1])
+		if len(tokens) == 0 {
+			return errorSyntax
+		}
+
+		matched := other.IsValid() && tokenOneOf(formatValue(other), tokens)
+		if matched == when && isZero(value) {
+			return errorValidation
+		}
+
+		return nil
+	}
+}
+
+// resolveField finds a field by path starting from the nearest parent struct.
+// Leading ".." moves one level up, dots between names go down into nested structs.
+// It returns an invalid value if a pointer on the path is nil.
+func resolveField(parents []reflect.Value, path string) (reflect.Value, error) {
+	level := 0
+	for strings.HasPrefix(path, "..") {
+		path = path[2:]
+		level++
+	}
+
+	if level >= len(parents) || path == "" {
+		return reflect.Value{}, fmt.Errorf("could not find field %q", path)
+	}
+
+	value := parents[len(parents)-1-level]
+
+	for _, name := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return reflect.Value{}, nil
+			}
+
+			value = value.Elem()
+		}
+
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("could not find field %q", name)
+		}
+
+		if value = value.FieldByName(name); !value.IsValid() {
+			return reflect.Value{}, fmt.Errorf("could not find field %q", name)
+		}
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, nil
+		}
+
+		value = value.Elem()
+	}
+
+	return value, nil
+}
+
+// nolint:gochecknoglobals
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareValues compares numbers, strings, booleans and time.Time values.
+// It returns false if the values could not be compared.
+// nolint:gocognit
+func compareValues(a, b reflect.Value) (int, bool) {
+	switch {
+	case isInt(a) && isInt(b):
+		return compareOrdered(a.Int() < b.Int(), a.Int() > b.Int()), true
+	case isUint(a) && isUint(b):
+		return compareOrdered(a.Uint() < b.Uint(), a.Uint() > b.Uint()), true
+	case isNumber(a) && isNumber(b):
+		x, y := toFloat(a), toFloat(b)
+		return compareOrdered(x < y, x > y), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0, true
+		}
+
+		return 1, true
+	case a.Type() == timeType && b.Type() == timeType:
+		x, y := timeOf(a), timeOf(b)
+		return compareOrdered(x.Before(y), x.After(y)), true
+	}
+
+	return 0, false
+}
+
+// timeOf reads a time.Time value, also if it is obtained by unexported fields and could not be converted
+// to an interface. Such a value is read in place if it is addressable, otherwise field by field.
+func timeOf(v reflect.Value) time.Time {
+	if v.CanInterface() {
+		return v.Interface().(time.Time)
+	}
+
+	if v.CanAddr() {
+		return *(*time.Time)(unsafe.Pointer(v.UnsafeAddr()))
+	}
+
+	var t time.Time
+
+	c := reflect.ValueOf(&t).Elem()
+
+	for i := 0; i < c.NumField(); i++ {
+		f := reflect.NewAt(c.Field(i).Type(), unsafe.Pointer(c.Field(i).UnsafeAddr())).Elem()
+
+		switch src := v.Field(i); src.Kind() {
+		case reflect.Uint64:
+			f.SetUint(src.Uint())
+		case reflect.Int64:
+			f.SetInt(src.Int())
+		case reflect.Ptr:
+			f.Set(reflect.NewAt(src.Type().Elem(), unsafe.Pointer(src.Pointer())))
+		}
+	}
+
+	return t
+}
+
+// compareOrdered converts results of comparisons to -1, 0 or 1.
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+
+	return false
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+// formatValue formats a value of a basic kind to compare it with tokens of a validator.
+func formatValue(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		return time.Duration(v.Int()).String()
+	case isInt(v):
+		return fmt.Sprint(v.Int())
+	case isUint(v):
+		return fmt.Sprint(v.Uint())
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return fmt.Sprint(v.Float())
+	case v.Kind() == reflect.Bool:
+		return fmt.Sprint(v.Bool())
+	case v.CanInterface():
+		return fmt.Sprint(v.Interface())
+	}
+
+	return ""
+}
+
+// isZero checks if a value is zero, i.e. missing.
+func isZero(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
@@ -0,0 +1,162 @@
+package validate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareFieldVal(t *testing.T) {
+	type Period struct {
+		StartTime time.Time
+		EndTime   time.Time `validate:"gtfield=StartTime"`
+		Min       int
+		Max       int `validate:"gtefield=Min"`
+	}
+
+	now := time.Now()
+
+	if nil != Validate(Period{StartTime: now, EndTime: now.Add(time.Hour), Min: 1, Max: 1}) {
+		t.Errorf("gtfield validator does not validate")
+	}
+
+	if nil == Validate(Period{StartTime: now, EndTime: now, Min: 1, Max: 1}) {
+		t.Errorf("gtfield validator does not validate for time.Time")
+	}
+
+	if nil == Validate(Period{StartTime: now, EndTime: now.Add(time.Hour), Min: 2, Max: 1}) {
+		t.Errorf("gtefield validator does not validate for int")
+	}
+
+	type period struct {
+		start time.Time
+		end   time.Time   `validate:"gtfield=start"`
+		times []time.Time `validate:"sorted"`
+	}
+
+	later := now.Add(time.Hour).In(time.FixedZone("UTC+8", 8*60*60))
+
+	// unexported fields are read in place by a pointer, and field by field by a value
+	for _, p := range []interface{}{&period{start: now, end: later}, period{start: now, end: later}} {
+		if err := Validate(p); err != nil {
+			t.Errorf("gtfield validator does not validate unexported time.Time: %v", err)
+		}
+	}
+
+	if _, ok := Validate(&period{start: later, end: now}).(ErrorValidation); !ok {
+		t.Errorf("gtfield validator does not validate unexported time.Time")
+	}
+
+	if err := Validate(&period{end: later, times: []time.Time{now, later}}); err != nil {
+		t.Errorf("sorted validator does not validate unexported time.Time: %v", err)
+	}
+
+	if _, ok := Validate(period{end: later, times: []time.Time{later, now}}).(ErrorValidation); !ok {
+		t.Errorf("sorted validator does not validate unexported time.Time")
+	}
+
+	type Password struct {
+		Old       string
+		Password  string `validate:"nefield=Old"`
+		Password2 string `validate:"eqfield=Password"`
+	}
+
+	if nil != Validate(Password{Old: "a", Password: "b", Password2: "b"}) {
+		t.Errorf("eqfield validator does not validate")
+	}
+
+	if nil == Validate(Password{Old: "a", Password: "b", Password2: "c"}) {
+		t.Errorf("eqfield validator does not validate")
+	}
+
+	if nil == Validate(Password{Old: "a", Password: "a", Password2: "a"}) {
+		t.Errorf("nefield validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		limit  uint8
+		values []int `validate:"> ltefield=limit"`
+	}{
+		limit:  3,
+		values: []int{1, 4},
+	}) {
+		t.Errorf("ltefield validator does not validate slice elements")
+	}
+
+	if _, ok := Validate(struct {
+		field int `validate:"eqfield=missing"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("eqfield validator does not return syntax error for a missing field")
+	}
+}
+
+func TestCompareParentFieldVal(t *testing.T) {
+	type Address struct {
+		Region string `validate:"eqfield=..Region"`
+	}
+
+	type Customer struct {
+		Region  string
+		Address *Address
+		Billing Address
+		Country struct {
+			Region string
+		}
+		Shipping string `validate:"eqfield=Country.Region"`
+	}
+
+	c := Customer{Region: "eu", Address: &Address{Region: "eu"}, Billing: Address{Region: "eu"}, Shipping: "eu"}
+	c.Country.Region = "eu"
+
+	if nil != Validate(c) {
+		t.Errorf("eqfield validator does not validate parent field")
+	}
+
+	c.Billing.Region = "us"
+
+	if err := Validate(c); err == nil || err.(ErrorField).FieldPath() != "Billing.Region" {
+		t.Errorf("eqfield validator does not validate parent field: %v", err)
+	}
+
+	c.Billing.Region = "eu"
+	c.Country.Region = "us"
+
+	if nil == Validate(c) {
+		t.Errorf("eqfield validator does not validate nested field")
+	}
+}
+
+func TestRequiredIfVal(t *testing.T) {
+	type Account struct {
+		Kind    string
+		Company string `validate:"required_if=Kind business,enterprise"`
+		Name    string `validate:"required_unless=Kind business"`
+		Tax     *int   `validate:"required_if=Kind enterprise"`
+	}
+
+	if nil != Validate(Account{Kind: "personal", Name: "a"}) {
+		t.Errorf("required_if validator does not validate")
+	}
+
+	if nil == Validate(Account{Kind: "business"}) {
+		t.Errorf("required_if validator does not validate")
+	}
+
+	if nil != Validate(Account{Kind: "business", Company: "a"}) {
+		t.Errorf("required_unless validator does not validate")
+	}
+
+	if nil == Validate(Account{Kind: "personal"}) {
+		t.Errorf("required_unless validator does not validate")
+	}
+
+	if nil == Validate(Account{Kind: "enterprise", Company: "a", Name: "a"}) {
+		t.Errorf("required_if validator does not validate pointer")
+	}
+
+	if _, ok := Validate(struct {
+		Kind string
+		Name string `validate:"required_if=Kind"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("required_if validator does not return syntax error")
+	}
+}
@@ -12,7 +12,25 @@ This package supports most of the built-in types: int8, uint8, int16, uint16, in
 uint32, int64, uint64, int, uint, uintptr, float32, float64 and aliased types:
 time.Duration, byte (uint8), rune (int32).
 
-Following validators are available: gt, lt, gte, lte, empty, nil, enum, format.
+Following validators are available: gt, lt, gte, lte, empty, nil, enum, format,
+eqfield, nefield, gtfield, gtefield, ltfield, ltefield, required_if, required_unless,
+runes_eq, runes_gt, runes_lt, runes_gte, runes_lte, prefix, suffix, contains, excludes, regex, datetime, unique, sorted, distinct_values,
+postcode, postcode_field, one_of_required, all_or_none, mutually_exclusive, at_least and if conditions.
+
+Validators gt, lt, gte, lte compare a count of bytes in a string,
+use runes_gt, runes_lt, runes_gte, runes_lte to compare a count of characters.
+
+Time validation
+
+Validators eq, ne, gt, lt, gte, lte compare a time.Time with a RFC3339 timestamp, a date,
+or a time relative to now. Validator datetime checks a string by a layout of time.Parse.
+
+	type S struct {
+		Since    time.Time `validate:"gte=now-24h & lt=now"`
+		Birthday time.Time `validate:"gte=1900-01-01"`
+		Day      string    `validate:"datetime=2006-01-02"`
+		Zone     string    `validate:"format=timezone"`
+	}
 
 Basic usage
 
@@ -53,6 +71,15 @@ You can use a regular syntax to validate a slice/array. To validate slice/array
 		field []int `validate:"empty=false > enum=1,-1"`
 	}
 
+Validators unique and sorted check all elements of a slice or an array, distinct_values checks all values of a map.
+Elements which are structs could be compared by a field.
+
+	type S struct {
+		IDs   []int          `validate:"unique & sorted=asc"`
+		Items []Item         `validate:"unique=ID"`
+		Users map[int]string `validate:"distinct_values"`
+	}
+
 Map validation
 
 You can use a regular syntax to validate a map. To validate map keys, specify validators inside brackets.
@@ -136,6 +163,168 @@ Custom validation also works for a substuct, if a substruct is defined in an exp
 		return nil
 	}
 
+Use ValidatorWithContext to get the context of ValidateContext, e.g. a database handle or a deadline.
+Validation stops with the context error when the context is done.
+
+	func (s S) ValidateContext(ctx context.Context) error {
+		return checkUnique(ctx, s.field)
+	}
+
+	err := validate.ValidateContext(ctx, s)
+
+Validation groups
+
+A struct could be validated differently in different scenarios, e.g. on create and on update.
+A validate tag could contain rules for groups separated by semicolons. The rules of the first active group are used,
+otherwise the rules without a group. A dash stands for no rules.
+A groups tag limits a field to the given groups. Use Groups option to activate groups.
+Custom validators get the active groups from the context by GroupsFromContext.
+
+	type User struct {
+		ID    *int   `validate:"create:nil=true;update:nil=false"`
+		Name  string `validate:"patch:-;empty=false"`
+		Email string `validate:"format=email" groups:"create"`
+	}
+
+	err := validate.Validate(user, validate.Groups("create"))
+
+Partial validation
+
+Partial validates only the given field paths, e.g. for a PATCH request. Paths look like paths reported in errors,
+a field name below a slice or a map without an index selects the field of all elements.
+PartialMap takes the paths from keys of a decoded JSON object.
+Custom validators on the way to the selected paths are skipped unless PartialHooks option is used.
+
+	err := validate.Partial(order, "Name", "Address.City", "Items.Qty", "Items[0].Code")
+
+	option, _ := validate.New(validate.FieldNameTag("json"), validate.PartialHooks())
+	err := option.PartialMap(order, body) // body is a map[string]interface{}
+
+Map validation without structs
+
+Map validates a map, e.g. a decoded JSON object, against rules with the same grammar as validate tags.
+Rules are keyed by dotted paths, a star selects all elements of a slice or a map.
+A missing or null value is checked by nil validators only.
+
+	err := validate.Map(data, map[string]string{
+		"name":        "nil=false & empty=false",
+		"address.zip": "format=numeric",
+		"items.*.qty": "nil=false & gte=1",
+	})
+
+Cross-field validation
+
+Validators eqfield, nefield, gtfield, gtefield, ltfield and ltefield compare a value with another field.
+Validators required_if and required_unless require a value to be set depending on another field.
+A sibling field is referenced by its name, a nested one by a dotted path,
+and a field of a parent struct by leading dots, one ".." per level.
+
+	type Address struct {
+		Region string `validate:"eqfield=..Region"` // Should equal to Order.Region
+	}
+
+	type Order struct {
+		Region    string
+		Address   Address
+		StartTime time.Time
+		EndTime   time.Time `validate:"gtfield=StartTime"`
+		Kind      string
+		Company   string `validate:"required_if=Kind business,enterprise"`
+	}
+
+Conditional validation
+
+A validate tag could start with a condition like if=<condition> then <validators>,
+the validators run only if the condition is true. Conditions reference fields like cross-field validators
+and support literals, comparisons, &&, ||, !, parentheses, len() and in with a list.
+Syntax errors of conditions are reported as ErrorSyntax near the wrong position.
+
+	type Order struct {
+		Type   string
+		Amount int      `validate:"if=Type == \"vip\" then gte=100"`
+		Tags   []string `validate:"if=Type in [\"a\", \"b\"] && len(Tags) > 0 then lte=2 > empty=false"`
+	}
+
+Struct-level validation
+
+Validators one_of_required, all_or_none, mutually_exclusive and at_least check rules spanning several fields.
+Put them on a blank field, which stands for the struct itself, errors are reported for the struct.
+A field counts as set if it is not zero.
+
+	type Contact struct {
+		_       struct{} `validate:"one_of_required=Email,Phone & mutually_exclusive=Email,Phone"`
+		Email   string
+		Phone   string
+		Street  string
+		City    string
+		_       struct{} `validate:"all_or_none=Street,City"`
+	}
+
+	type Payment struct {
+		_       struct{} `validate:"at_least=2 of Card,Account,Wallet"`
+		Card    string
+		Account string
+		Wallet  string
+	}
+
+Custom validators and formats
+
+You can register named validators and formats, they work with the same syntax as the built-in ones.
+Registrations are safe for concurrent use.
+Use Option methods to register them for the option only.
+
+	validate.RegisterValidator("luhn", func(value reflect.Value, validator string) error { ... })
+	validate.RegisterFormat("sku", func(value string) bool { ... })
+	validate.RegisterRegex("code", regexp.MustCompile(`^[A-Z]{3}$`))
+
+	type S struct {
+		Card string   `validate:"luhn=true"`
+		SKUs []string `validate:"empty=false > format=sku"`
+		Code string   `validate:"regex=code"`
+	}
+
+	option, _ := validate.New()
+	option.RegisterFormat("upper", func(value string) bool { ... })
+	err := option.Validate(s)
+
+Modifiers
+
+Modifiers in mod tag normalize strings in place before validators run, they are applied in order.
+Like in validate tag, ">" applies modifiers to elements of slices, values of maps and targets of pointers.
+Built-in modifiers are trim, lower, upper, title, squash_spaces and digits_only,
+more could be registered by RegisterModifier. Pass a pointer to modify fields of a struct,
+a field with modifiers which could not be set, e.g. of a struct passed by value or an unexported field,
+is reported as a syntax error whatever its value is.
+
+	type Signup struct {
+		Email string   `mod:"trim & lower" validate:"format=email"`
+		Phone string   `mod:"digits_only" validate:"format=numeric"`
+		Tags  []string `mod:"> trim"`
+	}
+
+	err := validate.Validate(&signup)
+
+Compiling validators
+
+Validators are parsed once per type and cached, so repeated validation does not parse tags again.
+Use Compile at startup to report syntax errors early, it checks validators of a type and all nested types.
+
+	func init() {
+		if err := validate.Compile(Request{}); err != nil {
+			panic(err) // err is ErrorSyntax
+		}
+	}
+
+JSON Schema
+
+JSONSchema generates a JSON Schema (draft 2020-12) document from validate tags.
+Comparison validators become minimum/maximum or minLength/maxItems etc., enum becomes enum,
+formats become JSON Schema formats or patterns, nil=false and empty=false make a property required.
+Property names are taken from json tags.
+
+	schema, err := validate.JSONSchema(Request{})
+	data, err := json.MarshalIndent(schema, "", "  ")
+
 Handling errors
 
 Validate method returns two types of errors: ErrorSyntax and ErrorValidation.
@@ -157,5 +346,35 @@ You can handle an error type using switch syntax.
 			// Handle other errors
 		}
 	}
+
+Error messages
+
+Use Locale option to get messages of validation errors from a message catalog.
+Catalogs for English ("en") and Simplified Chinese ("zh-CN") are available, more could be registered by RegisterMessages.
+Messages are templates with placeholders {field}, {value} and {param}.
+A field could have its own message in msg tag.
+
+	type S struct {
+		Name string `validate:"gte=3"`
+		Age  int    `validate:"gte=18" msg:"{field} is {value}, should be at least {param}"`
+	}
+
+	err := validate.Validate(S{Name: "a"}, validate.Locale("zh-CN")) // Name必须大于或等于3
+
+Collecting all errors
+
+By default validation stops at the first error. Use CollectAll option to walk the whole value
+and get all errors as Errors. Every entry reports its full path, e.g. Items[3].Tags["env"].
+
+Errors report Go field names. Use FieldNameTag option to report names from another tag, e.g. json.
+
+	if err := validate.Validate(order, validate.CollectAll(), validate.FieldNameTag("json")); err != nil {
+		var errs validate.Errors
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				fmt.Println(e.FieldPath(), e)
+			}
+		}
+	}
 */
 package validate
@@ -5,12 +5,14 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // ErrorField is an error interface for field/value error.
 type ErrorField interface {
 	error
 	FieldName() string
+	FieldPath() string
 }
 
 // errorField is a setter interface
@@ -21,9 +23,13 @@ type errorField interface {
 // ErrorValidation occurs when validator does not validate.
 type ErrorValidation struct {
 	fieldName      string
+	fieldPath      string
 	fieldValue     reflect.Value
 	validatorType  Type
 	validatorValue string
+	err            error
+	// message is a message template, see Messages.
+	message string
 }
 
 // FieldName gets a field name.
@@ -31,6 +37,16 @@ func (e ErrorValidation) FieldName() string {
 	return e.fieldName
 }
 
+// Unwrap returns the error returned by a custom validator func, if any.
+func (e ErrorValidation) Unwrap() error {
+	return e.err
+}
+
+// FieldPath gets the full path of a field, e.g. Items[3].Tags["env"].
+func (e ErrorValidation) FieldPath() string {
+	return e.fieldPath
+}
+
 // setFieldName sets a field name.
 func (e *ErrorValidation) setFieldName(fieldName string) {
 	e.fieldName = fieldName
@@ -38,21 +54,44 @@ func (e *ErrorValidation) setFieldName(fieldName string) {
 
 // Error returns an error.
 func (e ErrorValidation) Error() string {
+	if e.message != "" {
+		return renderMessage(e.message, e)
+	}
+
 	validator := string(e.validatorType)
 	if len(e.validatorValue) > 0 {
 		validator += "=" + e.validatorValue
 	}
 
-	if len(e.fieldName) > 0 {
-		return fmt.Sprintf("Validation error in field \"%v\" of type \"%v\" using validator \"%v\"", e.fieldName, e.fieldValue.Type(), validator)
+	fieldName := e.fieldPath
+	if len(fieldName) == 0 {
+		fieldName = e.fieldName
+	}
+
+	// a missing value, e.g. a missing key checked by Map, has no type
+	of := ""
+	if e.fieldValue.IsValid() {
+		of = fmt.Sprintf(" of type \"%v\"", e.fieldValue.Type())
+	}
+
+	var msg string
+	if len(fieldName) > 0 {
+		msg = fmt.Sprintf("Validation error in field \"%v\"%s using validator \"%v\"", fieldName, of, validator)
+	} else {
+		msg = fmt.Sprintf("Validation error in value%s using validator \"%v\"", of, validator)
+	}
+
+	if e.err != nil {
+		msg += ": " + e.err.Error()
 	}
 
-	return fmt.Sprintf("Validation error in value of type \"%v\" using validator \"%v\"", e.fieldValue.Type(), validator)
+	return msg
 }
 
 // ErrorSyntax occurs when there is a syntax error.
 type ErrorSyntax struct {
 	fieldName  string
+	fieldPath  string
 	expression string
 	near       string
 	comment    string
@@ -63,6 +102,11 @@ func (e ErrorSyntax) FieldName() string {
 	return e.fieldName
 }
 
+// FieldPath gets the full path of a field.
+func (e ErrorSyntax) FieldPath() string {
+	return e.fieldPath
+}
+
 // setFieldName sets a field name.
 func (e *ErrorSyntax) setFieldName(fieldName string) {
 	e.fieldName = fieldName
@@ -70,6 +114,10 @@ func (e *ErrorSyntax) setFieldName(fieldName string) {
 
 // Error returns an error.
 func (e ErrorSyntax) Error() string {
+	if len(e.fieldPath) > 0 {
+		return fmt.Sprintf("Syntax error when validating field \"%v\", expression \"%v\" near \"%v\": %v", e.fieldPath, e.expression, e.near, e.comment)
+	}
+
 	if len(e.fieldName) > 0 {
 		return fmt.Sprintf("Syntax error when validating field \"%v\", expression \"%v\" near \"%v\": %v", e.fieldName, e.expression, e.near, e.comment)
 	}
@@ -100,3 +148,115 @@ func setFieldName(err ErrorField, fieldName string) ErrorField {
 
 	return err
 }
+
+// ErrorCustom wraps an error returned by a custom Validator when all errors are collected.
+type ErrorCustom struct {
+	fieldName string
+	fieldPath string
+	err       error
+}
+
+// FieldName gets a field name.
+func (e ErrorCustom) FieldName() string {
+	return e.fieldName
+}
+
+// FieldPath gets the full path of a field.
+func (e ErrorCustom) FieldPath() string {
+	return e.fieldPath
+}
+
+// Error returns an error.
+func (e ErrorCustom) Error() string {
+	if len(e.fieldPath) > 0 {
+		return fmt.Sprintf("Custom validation error in field \"%v\": %v", e.fieldPath, e.err)
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the error returned by the custom validator.
+func (e ErrorCustom) Unwrap() error {
+	return e.err
+}
+
+// Errors is a list of field errors returned when all errors are collected, see CollectAll.
+type Errors []ErrorField
+
+// Error returns an error.
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the entries, so errors.Is and errors.As can match any of them.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// append appends an error to the list, flattening nested lists.
+func (e Errors) append(err error) Errors {
+	switch v := err.(type) {
+	case Errors:
+		return append(e, v...)
+	case ErrorField:
+		return append(e, v)
+	default:
+		return append(e, ErrorCustom{err: err})
+	}
+}
+
+// errorOrNil returns nil for an empty list.
+func (e Errors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// withPath prepends a path segment to the field path of the error.
+// Segments are field names or element accessors like [3] and ["env"].
+func withPath(err error, segment string) error {
+	switch e := err.(type) {
+	case ErrorValidation:
+		e.fieldPath = joinPath(segment, e.fieldPath)
+		return e
+	case ErrorSyntax:
+		e.fieldPath = joinPath(segment, e.fieldPath)
+		return e
+	case ErrorCustom:
+		e.fieldPath = joinPath(segment, e.fieldPath)
+		return e
+	case Errors:
+		errs := make(Errors, len(e))
+		for i, v := range e {
+			errs[i] = withPath(v, segment).(ErrorField)
+		}
+
+		return errs
+	}
+
+	return err
+}
+
+// joinPath joins a path segment with the rest of a path.
+func joinPath(segment, path string) string {
+	switch {
+	case segment == "":
+		return path
+	case path == "", strings.HasPrefix(path, "["):
+		return segment + path
+	default:
+		return segment + "." + path
+	}
+}
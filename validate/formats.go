@@ -5,10 +5,16 @@ package validate
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"mime"
 	"net"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	urn "github.com/leodido/go-urn"
 )
@@ -67,62 +73,95 @@ const (
 	FormatURLEncoded                      = "url_encoded"
 	FormatDir                             = "dir"
 	FormatPostcode                        = "postcode"
+	FormatRFC3339                         = "rfc3339"
+	FormatDate                            = "date"
+	FormatTimezone                        = "timezone"
+	FormatE164                            = "e164"      // phone numbers like +8613800138000
+	FormatCNMobile                        = "cn_mobile" // Chinese mainland mobile numbers
+	FormatCNID                            = "cn_id"     // Chinese mainland resident ID numbers
+	FormatIBAN                            = "iban"
+	FormatCreditCard                      = "credit_card"
+	FormatSemver                          = "semver"
+	FormatCron                            = "cron"
+	FormatJWT                             = "jwt"
+	FormatMIME                            = "mime"
+	FormatISO3166Alpha2                   = "iso3166_alpha2"
+	FormatISO3166Alpha3                   = "iso3166_alpha3"
+	FormatISO4217                         = "iso4217"
 )
 
-// formatFunc is an interface for format validator func
-type formatFunc func(value string) bool
-
-func getFormatTypeMap() map[FormatType]formatFunc {
-	return map[FormatType]formatFunc{
-		FormatAlpha:                formatAlpha,
-		FormatAlnum:                formatAlnum,
-		FormatAlphaUnicode:         formatAlphaUnicode,
-		FormatAlnumUnicode:         formatAlnumUnicode,
-		FormatNumeric:              formatNumeric,
-		FormatNumber:               formatNumber,
-		FormatHexadecimal:          formatHexadecimal,
-		FormatHEXColor:             formatHEXColor,
-		FormatRGB:                  formatRGB,
-		FormatRGBA:                 formatRGBA,
-		FormatHSL:                  formatHSL,
-		FormatHSLA:                 formatHSLA,
-		FormatEmail:                formatEmail,
-		FormatURL:                  formatURL,
-		FormatURI:                  formatURI,
-		FormatUrnRFC2141:           formatUrnRFC2141,
-		FormatFile:                 formatFile,
-		FormatBase64:               formatBase64,
-		FormatBase64URL:            formatBase64URL,
-		FormatISBN:                 formatISBN,
-		FormatISBN10:               formatISBN10,
-		FormatISBN13:               formatISBN13,
-		FormatEthereumAddress:      formatEthereumAddress,
-		FormatBitcoinAddress:       formatBitcoinAddress,
-		FormatBitcoinBech32Address: formatBitcoinBech32Address,
-		FormatUUID:                 formatUUID,
-		FormatUUID3:                formatUUID3,
-		FormatUUID4:                formatUUID4,
-		FormatUUID5:                formatUUID5,
-		FormatASCII:                formatASCII,
-		FormatPrintableASCII:       formatPrintableASCII,
-		FormatDataURI:              formatDataURI,
-		FormatLatitude:             formatLatitude,
-		FormatLongitude:            formatLongitude,
-		FormatSSN:                  formatSSN,
-		FormatIPv4:                 formatIPv4,
-		FormatIPv6:                 formatIPv6,
-		FormatIP:                   formatIP,
-		FormatCIDRv4:               formatCIDRv4,
-		FormatCIDRv6:               formatCIDRv6,
-		FormatCIDR:                 formatCIDR,
-		FormatMAC:                  formatMAC,
-		FormatHostnameRFC952:       formatHostnameRFC952,
-		FormatHostnameRFC1123:      formatHostnameRFC1123,
-		FormatFQDN:                 formatFQDN,
-		FormatURLEncoded:           formatURLEncoded,
-		FormatDir:                  formatDir,
-		FormatPostcode:             formatPostcode,
-	}
+// FormatFunc is an interface for format validator func
+type FormatFunc func(value string) bool
+
+// nolint:gochecknoglobals
+var formatTypeMap = map[FormatType]FormatFunc{
+	FormatAlpha:                formatAlpha,
+	FormatAlnum:                formatAlnum,
+	FormatAlphaUnicode:         formatAlphaUnicode,
+	FormatAlnumUnicode:         formatAlnumUnicode,
+	FormatNumeric:              formatNumeric,
+	FormatNumber:               formatNumber,
+	FormatHexadecimal:          formatHexadecimal,
+	FormatHEXColor:             formatHEXColor,
+	FormatRGB:                  formatRGB,
+	FormatRGBA:                 formatRGBA,
+	FormatHSL:                  formatHSL,
+	FormatHSLA:                 formatHSLA,
+	FormatEmail:                formatEmail,
+	FormatURL:                  formatURL,
+	FormatURI:                  formatURI,
+	FormatUrnRFC2141:           formatUrnRFC2141,
+	FormatFile:                 formatFile,
+	FormatBase64:               formatBase64,
+	FormatBase64URL:            formatBase64URL,
+	FormatISBN:                 formatISBN,
+	FormatISBN10:               formatISBN10,
+	FormatISBN13:               formatISBN13,
+	FormatEthereumAddress:      formatEthereumAddress,
+	FormatBitcoinAddress:       formatBitcoinAddress,
+	FormatBitcoinBech32Address: formatBitcoinBech32Address,
+	FormatUUID:                 formatUUID,
+	FormatUUID3:                formatUUID3,
+	FormatUUID4:                formatUUID4,
+	FormatUUID5:                formatUUID5,
+	FormatASCII:                formatASCII,
+	FormatPrintableASCII:       formatPrintableASCII,
+	FormatDataURI:              formatDataURI,
+	FormatLatitude:             formatLatitude,
+	FormatLongitude:            formatLongitude,
+	FormatSSN:                  formatSSN,
+	FormatIPv4:                 formatIPv4,
+	FormatIPv6:                 formatIPv6,
+	FormatIP:                   formatIP,
+	FormatCIDRv4:               formatCIDRv4,
+	FormatCIDRv6:               formatCIDRv6,
+	FormatCIDR:                 formatCIDR,
+	FormatMAC:                  formatMAC,
+	FormatHostnameRFC952:       formatHostnameRFC952,
+	FormatHostnameRFC1123:      formatHostnameRFC1123,
+	FormatFQDN:                 formatFQDN,
+	FormatURLEncoded:           formatURLEncoded,
+	FormatDir:                  formatDir,
+	FormatPostcode:             formatPostcode,
+	FormatRFC3339:              formatRFC3339,
+	FormatDate:                 formatDate,
+	FormatTimezone:             formatTimezone,
+	FormatE164:                 formatE164,
+	FormatCNMobile:             formatCNMobile,
+	FormatCNID:                 formatCNID,
+	FormatIBAN:                 formatIBAN,
+	FormatCreditCard:           formatCreditCard,
+	FormatSemver:               formatSemver,
+	FormatCron:                 formatCron,
+	FormatJWT:                  formatJWT,
+	FormatMIME:                 formatMIME,
+	FormatISO3166Alpha2:        formatISO3166Alpha2,
+	FormatISO3166Alpha3:        formatISO3166Alpha3,
+	FormatISO4217:              formatISO4217,
+}
+
+func getFormatTypeMap() map[FormatType]FormatFunc {
+	return formatTypeMap
 }
 
 func formatURLEncoded(value string) bool {
@@ -590,7 +629,179 @@ func formatDir(value string) bool {
 	return fileInfo.IsDir()
 }
 
-// formatPostcode is the validation function for validating if the current field's value is a valid postcode.
+// formatPostcode is the validation function for validating if the current field's value is a valid postcode of the United Kingdom.
 func formatPostcode(value string) bool {
 	return postcodeRegex.MatchString(value)
 }
+
+// formatRFC3339 is the validation function for validating if the current field's value is a valid RFC3339 timestamp.
+func formatRFC3339(value string) bool {
+	_, err := time.Parse(time.RFC3339Nano, value)
+
+	return err == nil
+}
+
+// formatDate is the validation function for validating if the current field's value is a valid date like 2006-01-02.
+func formatDate(value string) bool {
+	_, err := time.Parse("2006-01-02", value)
+
+	return err == nil
+}
+
+// formatTimezone is the validation function for validating if the current field's value is a valid IANA time zone name.
+func formatTimezone(value string) bool {
+	if value == "" || strings.EqualFold(value, "local") {
+		return false
+	}
+
+	_, err := time.LoadLocation(value)
+
+	return err == nil
+}
+
+// formatE164 is the validation function for validating if the current field's value is a valid E.164 phone number.
+func formatE164(value string) bool {
+	return e164Regex.MatchString(value)
+}
+
+// formatCNMobile is the validation function for validating if the current field's value is
+// a valid Chinese mainland mobile number, with an optional +86 prefix.
+func formatCNMobile(value string) bool {
+	return cnMobileRegex.MatchString(value)
+}
+
+// formatCNID is the validation function for validating if the current field's value is
+// a valid 18-digit Chinese mainland resident ID number, the province, the birth date and the checksum are checked.
+func formatCNID(value string) bool {
+	if !cnIDRegex.MatchString(value) || !cnProvinceCodes[value[:2]] {
+		return false
+	}
+
+	if _, err := time.Parse("20060102", value[6:14]); err != nil {
+		return false
+	}
+
+	weights := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+
+	for i, w := range weights {
+		sum += int(value[i]-'0') * w
+	}
+
+	return "10X98765432"[sum%11] == strings.ToUpper(value)[17]
+}
+
+// formatIBAN is the validation function for validating if the current field's value is a valid IBAN,
+// spaces are allowed between groups, the length of the country and the mod-97 checksum are checked.
+func formatIBAN(value string) bool {
+	s := strings.ToUpper(strings.Replace(value, " ", "", -1))
+
+	if !iBANRegex.MatchString(s) || ibanLengths[s[:2]] != len(s) {
+		return false
+	}
+
+	// move the country code and check digits to the end, letters are replaced by 10 to 35
+	var digits strings.Builder
+
+	for _, c := range s[4:] + s[:4] {
+		if c >= 'A' && c <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			digits.WriteRune(c)
+		}
+	}
+
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// formatCreditCard is the validation function for validating if the current field's value is
+// a valid credit card number, spaces and dashes are allowed between groups, the Luhn checksum is checked.
+func formatCreditCard(value string) bool {
+	s := strings.NewReplacer(" ", "", "-", "").Replace(value)
+
+	if len(s) < 12 || len(s) > 19 || !numericRegex.MatchString(s) {
+		return false
+	}
+
+	sum := 0
+
+	for i := 0; i < len(s); i++ {
+		digit := int(s[len(s)-1-i] - '0')
+		if i%2 == 1 {
+			if digit *= 2; digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// formatSemver is the validation function for validating if the current field's value is a valid semantic version.
+func formatSemver(value string) bool {
+	return semverRegex.MatchString(value)
+}
+
+// formatCron is the validation function for validating if the current field's value is a valid cron expression.
+func formatCron(value string) bool {
+	return isCron(value)
+}
+
+// formatJWT is the validation function for validating if the current field's value is a structurally valid JWT,
+// the header and the payload must be JSON objects encoded in base64url, the signature is not verified.
+func formatJWT(value string) bool {
+	if !jWTRegex.MatchString(value) {
+		return false
+	}
+
+	parts := strings.Split(value, ".")
+
+	for _, part := range parts[:2] {
+		b, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return false
+		}
+
+		var obj map[string]interface{}
+		if json.Unmarshal(b, &obj) != nil || obj == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatMIME is the validation function for validating if the current field's value is a valid MIME type
+// like text/plain; charset=utf-8, the top-level type must be registered.
+func formatMIME(value string) bool {
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return false
+	}
+
+	parts := strings.SplitN(mediaType, "/", 2)
+
+	return len(parts) == 2 && parts[1] != "" && mimeTopLevelTypes[parts[0]]
+}
+
+// formatISO3166Alpha2 is the validation function for validating if the current field's value is
+// a valid ISO 3166-1 alpha-2 country code like CN.
+func formatISO3166Alpha2(value string) bool {
+	return iso3166Alpha2Codes[value]
+}
+
+// formatISO3166Alpha3 is the validation function for validating if the current field's value is
+// a valid ISO 3166-1 alpha-3 country code like CHN.
+func formatISO3166Alpha3(value string) bool {
+	return iso3166Alpha3Codes[value]
+}
+
+// formatISO4217 is the validation function for validating if the current field's value is
+// a valid ISO 4217 currency code like CNY.
+func formatISO4217(value string) bool {
+	return iso4217Codes[value]
+}
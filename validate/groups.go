@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// DefaultGroup is the group active when Groups option is not set.
+const DefaultGroup = "default"
+
+// Groups defines the active validation groups.
+//
+// A validate tag could contain rules for groups separated by semicolons, e.g. `validate:"create:nil=false;update:-"`.
+// The rules of the first group, which is active, are used, otherwise the rules without a group.
+// A dash stands for no rules.
+// A groups tag limits a field to the given groups, e.g. `groups:"create,update"`.
+// Without Groups option only DefaultGroup is active.
+func Groups(groups ...string) OptionFn { return func(o *Option) { o.Groups = groups } }
+
+// GroupsTagName defines the tag name to limit a field to the given groups.
+func GroupsTagName(tagName string) OptionFn { return func(o *Option) { o.GroupsTagName = tagName } }
+
+// groupsKey is the context key of the active groups.
+type groupsKey struct{}
+
+// GroupsFromContext returns the active groups of the context passed to ValidatorWithContext.
+func GroupsFromContext(ctx context.Context) []string {
+	if groups, ok := ctx.Value(groupsKey{}).([]string); ok {
+		return groups
+	}
+
+	return []string{DefaultGroup}
+}
+
+// activeGroups returns the active groups of the option.
+func (o *Option) activeGroups() []string {
+	if len(o.Groups) == 0 {
+		return []string{DefaultGroup}
+	}
+
+	return o.Groups
+}
+
+// isGroupActive checks if any of the groups is active.
+func (o *Option) isGroupActive(groups []string) bool {
+	for _, active := range o.activeGroups() {
+		for _, group := range groups {
+			if group == active {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// nolint:gochecknoglobals
+var regexpGroupRules = regexp.MustCompile(`^\s*([[:alpha:]_][[:alnum:]_]*(?:\s*,\s*[[:alpha:]_][[:alnum:]_]*)*)\s*:(.*)$`)
+
+// selectValidators selects validators of the active groups from a validate tag and a groups tag.
+func (o *Option) selectValidators(validators, groups string) string {
+	if groups != "" && !o.isGroupActive(splitGroups(groups)) {
+		return ""
+	}
+
+	if !strings.Contains(validators, ":") {
+		return validators
+	}
+
+	defaultRules, found := "", false
+
+	for _, part := range strings.Split(validators, ";") {
+		m := regexpGroupRules.FindStringSubmatch(part)
+		if m == nil {
+			defaultRules = part
+			continue
+		}
+
+		if !found && o.isGroupActive(splitGroups(m[1])) {
+			validators, found = m[2], true
+		}
+	}
+
+	if !found {
+		validators = defaultRules
+	}
+
+	if strings.TrimSpace(validators) == "-" {
+		return ""
+	}
+
+	return validators
+}
+
+// splitGroups splits comma separated groups.
+func splitGroups(groups string) []string {
+	items := strings.Split(groups, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+
+	return items
+}
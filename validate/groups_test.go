@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type groupsValidator struct {
+	groups []string
+}
+
+func (g *groupsValidator) ValidateContext(ctx context.Context) error {
+	g.groups = GroupsFromContext(ctx)
+	return nil
+}
+
+func TestGroups(t *testing.T) {
+	type User struct {
+		ID    *int   `validate:"create:nil=true;update:nil=false"`
+		Name  string `validate:"create,update:empty=false;patch:-;gte=0"`
+		Email string `validate:"format=email" groups:"create"`
+		Note  string `validate:"lte=3"`
+	}
+
+	id := 1
+
+	tests := []struct {
+		user   User
+		groups []string
+		fails  bool
+	}{
+		{User{}, nil, false},
+		{User{Note: "abcd"}, nil, true},
+		{User{ID: &id}, nil, false},
+		{User{Name: "a", Email: "a@b.c"}, []string{"create"}, false},
+		{User{ID: &id, Name: "a", Email: "a@b.c"}, []string{"create"}, true},
+		{User{Email: "a@b.c"}, []string{"create"}, true},
+		{User{Name: "a"}, []string{"create"}, true},
+		{User{ID: &id, Name: "a"}, []string{"update"}, false},
+		{User{Name: "a"}, []string{"update"}, true},
+		{User{}, []string{"patch"}, false},
+		{User{Note: "abcd"}, []string{"patch"}, true},
+		{User{Name: "a", Email: "a@b.c"}, []string{"patch", "create"}, false},
+	}
+
+	for i, test := range tests {
+		if err := Validate(test.user, Groups(test.groups...)); (err != nil) != test.fails {
+			t.Errorf("%d: validate with groups %v returns %v", i, test.groups, err)
+		}
+	}
+
+	if _, ok := Validate(struct {
+		field int `validate:"create:gte=a"`
+	}{}, Groups("create")).(ErrorSyntax); !ok {
+		t.Errorf("validate with groups does not report syntax error")
+	}
+}
+
+func TestGroupsFromContext(t *testing.T) {
+	g := &groupsValidator{}
+
+	if err := Validate(g, Groups("create", "admin")); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(g.groups, []string{"create", "admin"}) {
+		t.Errorf("custom validator does not see active groups %v", g.groups)
+	}
+
+	if err := Validate(g); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(g.groups, []string{DefaultGroup}) {
+		t.Errorf("custom validator does not see default group %v", g.groups)
+	}
+}
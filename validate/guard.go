@@ -0,0 +1,513 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// If is a guard in front of validators, the validators run only if the condition is true.
+// The condition is an expression over fields, which are referenced like in cross-field validators.
+// It supports literals (strings, numbers, true, false, nil), comparisons (==, !=, <, <=, >, >=),
+// boolean operators (&&, ||, !), parentheses, len() and in with a list, e.g. Kind in ["a", "b"].
+// A field which is not a bool is true if it is not zero.
+// E.g. `validate:"if=Type == \"vip\" && len(Tags) > 0 then gte=100"`
+const If Type = "if"
+
+// guard is a compiled condition of an if expression.
+type guard struct {
+	// text is the condition text, it is used to report errors.
+	text string
+	root *guardNode
+	// fields contains the referenced field paths, they are checked by Compile.
+	fields []string
+}
+
+// guardNode is a node of a condition, op is the operator or the kind of an operand.
+type guardNode struct {
+	op    string
+	value reflect.Value
+	path  string
+	args  []*guardNode
+}
+
+// Following kinds of operands are available in guard nodes besides operators.
+const (
+	guardLiteral = "literal"
+	guardField   = "field"
+	guardLen     = "len"
+)
+
+// guardToken is a token of a condition, pos is the offset in the condition text.
+type guardToken struct {
+	kind string
+	text string
+	pos  int
+}
+
+// Following kinds of tokens are available besides operators and punctuation.
+const (
+	tokenString  = "string"
+	tokenNumber  = "number"
+	tokenIdent   = "ident"
+	tokenEOF     = "eof"
+	tokenInvalid = "invalid"
+)
+
+// cutGuard cuts a condition from validators like `if=<condition> then <validators>`.
+// It returns false if the validators do not start with a guard.
+func cutGuard(validators string) (*guard, string, bool, ErrorField) {
+	text := strings.TrimLeftFunc(validators, unicode.IsSpace)
+	if !strings.HasPrefix(text, string(If)+"=") {
+		return nil, "", false, nil
+	}
+
+	text = text[len(If)+1:]
+
+	p := &guardParser{text: text}
+	if err := p.tokenize(); err != nil {
+		return nil, "", true, err
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, "", true, err
+	}
+
+	if t := p.peek(); t.kind != tokenIdent || t.text != "then" {
+		return nil, "", true, p.errorAt(t, "expected then")
+	}
+
+	rest := text[p.peek().pos+len("then"):]
+
+	return &guard{text: strings.TrimSpace(text[:p.peek().pos]), root: root, fields: p.fields}, rest, true, nil
+}
+
+// guardParser parses a condition by recursive descent.
+type guardParser struct {
+	text   string
+	tokens []guardToken
+	i      int
+	fields []string
+}
+
+func (p *guardParser) errorAt(t guardToken, comment string) ErrorField {
+	return ErrorSyntax{
+		expression: p.text,
+		near:       strings.TrimSpace(p.text[t.pos:]),
+		comment:    fmt.Sprintf("%s at position %d", comment, t.pos),
+	}
+}
+
+// tokenize splits the condition into tokens, it stops at then keyword or an invalid character.
+// nolint:gocognit,funlen
+func (p *guardParser) tokenize() ErrorField {
+	text := p.text
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+
+			continue
+		case c == '"':
+			end := i + 1
+			for ; end < len(text) && text[end] != '"'; end++ {
+				if text[end] == '\\' {
+					end++
+				}
+			}
+
+			if end >= len(text) {
+				return p.errorAt(guardToken{pos: i}, "unterminated string")
+			}
+
+			p.tokens = append(p.tokens, guardToken{kind: tokenString, text: text[i : end+1], pos: i})
+			i = end + 1
+
+			continue
+		case c >= '0' && c <= '9':
+			end := i
+			for end < len(text) && (text[end] >= '0' && text[end] <= '9' || text[end] == '.') {
+				end++
+			}
+
+			p.tokens = append(p.tokens, guardToken{kind: tokenNumber, text: text[i:end], pos: i})
+			i = end
+
+			continue
+		case c == '_' || c == '.' || unicode.IsLetter(rune(c)):
+			end := i
+			for end < len(text) && (text[end] == '_' || text[end] == '.' ||
+				unicode.IsLetter(rune(text[end])) || unicode.IsDigit(rune(text[end]))) {
+				end++
+			}
+
+			t := guardToken{kind: tokenIdent, text: text[i:end], pos: i}
+			p.tokens = append(p.tokens, t)
+
+			if t.text == "then" {
+				return nil
+			}
+
+			i = end
+
+			continue
+		}
+
+		op := ""
+
+		for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ",", "-"} {
+			if strings.HasPrefix(text[i:], candidate) {
+				op = candidate
+				break
+			}
+		}
+
+		if op == "" {
+			// the parser reports an error at the invalid token
+			p.tokens = append(p.tokens, guardToken{kind: tokenInvalid, text: text[i:], pos: i})
+			return nil
+		}
+
+		p.tokens = append(p.tokens, guardToken{kind: op, text: op, pos: i})
+		i += len(op)
+	}
+
+	p.tokens = append(p.tokens, guardToken{kind: tokenEOF, pos: len(text)})
+
+	return nil
+}
+
+func (p *guardParser) peek() guardToken { return p.tokens[p.i] }
+
+func (p *guardParser) next() guardToken {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF && t.kind != tokenInvalid && !(t.kind == tokenIdent && t.text == "then") {
+		p.i++
+	}
+
+	return t
+}
+
+func (p *guardParser) expect(kind string) (guardToken, ErrorField) {
+	t := p.next()
+	if t.kind != kind {
+		return t, p.errorAt(t, fmt.Sprintf("expected %s", kind))
+	}
+
+	return t, nil
+}
+
+func (p *guardParser) parseOr() (*guardNode, ErrorField) {
+	return p.parseBinary("||", p.parseAnd)
+}
+
+func (p *guardParser) parseAnd() (*guardNode, ErrorField) {
+	return p.parseBinary("&&", p.parseComparison)
+}
+
+func (p *guardParser) parseComparison() (*guardNode, ErrorField) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+
+	switch {
+	case t.kind == tokenIdent && t.text == "in":
+		p.next()
+
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+
+		return &guardNode{op: "in", args: append([]*guardNode{left}, list...)}, nil
+	case t.kind == "==" || t.kind == "!=" || t.kind == "<" || t.kind == "<=" || t.kind == ">" || t.kind == ">=":
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &guardNode{op: t.kind, args: []*guardNode{left, right}}, nil
+	}
+
+	return left, nil
+}
+
+func (p *guardParser) parseBinary(op string, operand func() (*guardNode, ErrorField)) (*guardNode, ErrorField) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if p.peek().kind != op {
+			return left, nil
+		}
+
+		p.next()
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &guardNode{op: op, args: []*guardNode{left, right}}
+	}
+}
+
+func (p *guardParser) parseUnary() (*guardNode, ErrorField) {
+	if p.peek().kind == "!" {
+		p.next()
+
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &guardNode{op: "!", args: []*guardNode{arg}}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+// nolint:gocyclo
+func (p *guardParser) parsePrimary() (*guardNode, ErrorField) {
+	t := p.next()
+
+	switch t.kind {
+	case "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case "-":
+		n, err := p.expect(tokenNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		n.text, n.pos = "-"+n.text, t.pos
+
+		return p.parseNumber(n)
+	case tokenNumber:
+		return p.parseNumber(t)
+	case tokenString:
+		s, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, p.errorAt(t, "could not parse string")
+		}
+
+		return &guardNode{op: guardLiteral, value: reflect.ValueOf(s)}, nil
+	case tokenIdent:
+		return p.parseIdent(t)
+	}
+
+	return nil, p.errorAt(t, "unexpected token")
+}
+
+func (p *guardParser) parseIdent(t guardToken) (*guardNode, ErrorField) {
+	switch t.text {
+	case "true", "false":
+		return &guardNode{op: guardLiteral, value: reflect.ValueOf(t.text == "true")}, nil
+	case "nil":
+		return &guardNode{op: guardLiteral}, nil
+	case "len":
+		if _, err := p.expect("("); err != nil {
+			return nil, err
+		}
+
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return &guardNode{op: guardLen, args: []*guardNode{arg}}, nil
+	case "in", "then":
+		return nil, p.errorAt(t, "unexpected token")
+	}
+
+	p.fields = append(p.fields, t.text)
+
+	return &guardNode{op: guardField, path: t.text}, nil
+}
+
+func (p *guardParser) parseNumber(t guardToken) (*guardNode, ErrorField) {
+	if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+		return &guardNode{op: guardLiteral, value: reflect.ValueOf(i)}, nil
+	}
+
+	f, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, p.errorAt(t, "could not parse number")
+	}
+
+	return &guardNode{op: guardLiteral, value: reflect.ValueOf(f)}, nil
+}
+
+func (p *guardParser) parseList() ([]*guardNode, ErrorField) {
+	if _, err := p.expect("["); err != nil {
+		return nil, err
+	}
+
+	var list []*guardNode
+
+	for p.peek().kind != "]" {
+		if len(list) > 0 {
+			if _, err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, node)
+	}
+
+	p.next()
+
+	return list, nil
+}
+
+// eval evaluates the condition against the parent structs of a field.
+func (g *guard) eval(parents []reflect.Value) (bool, ErrorField) {
+	v, err := g.root.eval(parents)
+	if err != nil {
+		return false, ErrorSyntax{expression: g.text, near: string(If), comment: err.Error()}
+	}
+
+	return truthy(v), nil
+}
+
+// nolint:gocognit,gocyclo
+func (n *guardNode) eval(parents []reflect.Value) (reflect.Value, error) {
+	switch n.op {
+	case guardLiteral:
+		return n.value, nil
+	case guardField:
+		return resolveField(parents, n.path)
+	}
+
+	args := make([]reflect.Value, len(n.args))
+
+	for i, arg := range n.args {
+		// && and || evaluate the right operand only when needed
+		if i == 1 && (n.op == "&&" && !truthy(args[0]) || n.op == "||" && truthy(args[0])) {
+			return reflect.ValueOf(n.op == "||"), nil
+		}
+
+		v, err := arg.eval(parents)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		args[i] = v
+	}
+
+	switch n.op {
+	case "&&", "||":
+		return reflect.ValueOf(truthy(args[1])), nil
+	case "!":
+		return reflect.ValueOf(!truthy(args[0])), nil
+	case guardLen:
+		switch args[0].Kind() {
+		case reflect.Invalid:
+			return reflect.ValueOf(int64(0)), nil
+		case reflect.String, reflect.Map, reflect.Slice, reflect.Array, reflect.Chan:
+			return reflect.ValueOf(int64(args[0].Len())), nil
+		}
+
+		return reflect.Value{}, fmt.Errorf("could not get length of %v", args[0].Type())
+	case "in":
+		for _, v := range args[1:] {
+			if c, err := compareGuardValues(args[0], v); err == nil && c == 0 {
+				return reflect.ValueOf(true), nil
+			}
+		}
+
+		return reflect.ValueOf(false), nil
+	}
+
+	c, err := compareGuardValues(args[0], args[1])
+	if err != nil {
+		if n.op != "==" && n.op != "!=" {
+			return reflect.Value{}, err
+		}
+
+		c = 1
+	}
+
+	accept := map[string]func(int) bool{
+		"==": func(c int) bool { return c == 0 },
+		"!=": func(c int) bool { return c != 0 },
+		"<":  func(c int) bool { return c < 0 },
+		"<=": func(c int) bool { return c <= 0 },
+		">":  func(c int) bool { return c > 0 },
+		">=": func(c int) bool { return c >= 0 },
+	}[n.op]
+
+	return reflect.ValueOf(accept(c)), nil
+}
+
+// compareGuardValues compares values of a condition, nil equals to missing and zero pointers, maps and slices.
+func compareGuardValues(a, b reflect.Value) (int, error) {
+	if !a.IsValid() || !b.IsValid() {
+		if isNilValue(a) && isNilValue(b) {
+			return 0, nil
+		}
+
+		return 1, fmt.Errorf("could not compare with nil")
+	}
+
+	if c, ok := compareValues(a, b); ok {
+		return c, nil
+	}
+
+	if a.CanInterface() && b.CanInterface() && reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return 0, nil
+	}
+
+	return 1, fmt.Errorf("could not compare %v with %v", a.Type(), b.Type())
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+// truthy converts a value to a bool, a value which is not a bool is true if it is not zero.
+func truthy(v reflect.Value) bool {
+	if v.Kind() == reflect.Bool {
+		return v.Bool()
+	}
+
+	return !isZero(v)
+}
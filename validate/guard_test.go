@@ -0,0 +1,120 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIfVal(t *testing.T) {
+	type Address struct {
+		Country string
+		Zip     string `validate:"if=Country == \"CN\" then runes_eq=6 & format=numeric"`
+		Phone   string `validate:"if=..Type in [\"vip\", \"svip\"] then empty=false"`
+	}
+
+	type St struct {
+		Type    string
+		Amount  int      `validate:"if=Type==\"vip\" then gte=100"`
+		Tags    []string `validate:"if=len(Tags) > 0 && !(Type == \"trial\") then lte=2 > empty=false"`
+		Limit   float64  `validate:"if=Address != nil && Amount >= 1000 || Type == \"admin\" then gte=-1.5 & lte=0"`
+		Address *Address
+	}
+
+	valid := []St{
+		{Type: "basic", Amount: 1},
+		{Type: "vip", Amount: 100},
+		{Type: "trial", Tags: []string{"a", "b", "c"}},
+		{Type: "basic", Tags: []string{"a", "b"}},
+		{Type: "basic", Amount: 1000, Limit: 10},
+		{Type: "vip", Amount: 1000, Address: &Address{Country: "CN", Zip: "100000", Phone: "123"}},
+		{Type: "basic", Address: &Address{Country: "US", Zip: "1"}},
+		{Type: "admin", Limit: -1},
+	}
+
+	for _, v := range valid {
+		if err := Validate(v); err != nil {
+			t.Errorf("if validator does not validate %+v: %v", v, err)
+		}
+	}
+
+	invalid := []struct {
+		value St
+		path  string
+	}{
+		{St{Type: "vip", Amount: 99}, "Amount"},
+		{St{Type: "basic", Tags: []string{"a", "b", "c"}}, "Tags"},
+		{St{Type: "basic", Tags: []string{"a", ""}}, "Tags[1]"},
+		{St{Type: "admin", Limit: 1}, "Limit"},
+		{St{Type: "vip", Amount: 1000, Limit: 5, Address: &Address{Phone: "1"}}, "Limit"},
+		{St{Type: "basic", Address: &Address{Country: "CN", Zip: "1000"}}, "Address.Zip"},
+		{St{Type: "svip", Address: &Address{}}, "Address.Phone"},
+	}
+
+	for _, c := range invalid {
+		err := Validate(c.value)
+		if err == nil {
+			t.Errorf("if validator does not validate %+v", c.value)
+			continue
+		}
+
+		if path := err.(ErrorField).FieldPath(); path != c.path {
+			t.Errorf("if validator reports %q for %+v, expected %q", path, c.value, c.path)
+		}
+	}
+}
+
+func TestIfSyntax(t *testing.T) {
+	cases := []struct {
+		tag  string
+		near string
+	}{
+		{`if=A == then gte=1`, "then gte=1"},
+		{`if=A == "x gte=1`, `"x gte=1`},
+		{`if=A == 1 gte=1`, "gte=1"},
+		{`if=A # 1 then gte=1`, "# 1 then gte=1"},
+		{`if=len A then gte=1`, "A then gte=1"},
+		{`if=A in "x" then gte=1`, `"x" then gte=1`},
+	}
+
+	for _, c := range cases {
+		expr := compileExpression(c.tag)
+
+		err, ok := expr.err.(ErrorSyntax)
+		if !ok {
+			t.Errorf("%s does not report a syntax error", c.tag)
+			continue
+		}
+
+		if err.near != c.near {
+			t.Errorf("%s reports a syntax error near %q, expected %q", c.tag, err.near, c.near)
+		}
+
+		if !strings.Contains(err.comment, "at position") {
+			t.Errorf("%s reports a syntax error without a position: %v", c.tag, err)
+		}
+	}
+
+	type St struct {
+		A int
+		B int `validate:"if=A < \"x\" then gte=1"`
+		C int `validate:"if=Missing == 1 then gte=1"`
+	}
+
+	if _, ok := Validate(St{}).(ErrorSyntax); !ok {
+		t.Errorf("if validator does not report a syntax error of a comparison")
+	}
+
+	if err := Compile(struct {
+		A int
+		B int `validate:"if=Missing == 1 then gte=1"`
+	}{}); err == nil {
+		t.Errorf("if validator does not report a missing field")
+	}
+
+	if err := Compile(struct {
+		A int
+		B int `validate:"if=A == 1 then unknown=1"`
+	}{}); err == nil {
+		t.Errorf("if validator does not report a syntax error after then")
+	}
+}
@@ -0,0 +1,294 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Map validates a map, e.g. a decoded JSON object, against rules with the grammar of validate tags.
+// Rules are keyed by dotted paths, e.g. "address.zip", a star selects all elements of a slice or a map,
+// e.g. "items.*.qty". Errors report paths like "items[0].qty".
+// A missing or null value is checked by nil validators only, e.g. "nil=false" makes a value required.
+// Cross-field validators are not supported.
+//
+//	err := validate.Map(data, map[string]string{
+//		"name":        "nil=false & empty=false",
+//		"address.zip": "format=numeric",
+//		"items.*.qty": "gte=1",
+//	})
+func Map(data map[string]interface{}, rules map[string]string, optionFns ...OptionFn) error {
+	option, err := createOption(optionFns)
+	if err != nil {
+		return err
+	}
+
+	return option.Map(data, rules)
+}
+
+// Map validates a map against rules with the option, see Map function.
+func (o *Option) Map(data map[string]interface{}, rules map[string]string) error {
+	return o.MapContext(context.Background(), data, rules)
+}
+
+// MapContext validates a map against rules like Map, the context is used like in ValidateContext.
+func (o *Option) MapContext(ctx context.Context, data map[string]interface{}, rules map[string]string) error {
+	if len(o.Groups) > 0 {
+		ctx = context.WithValue(ctx, groupsKey{}, o.Groups)
+	}
+
+	v := &validation{Option: o, ctx: ctx}
+
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+
+	// rules are checked in the same order every time
+	sort.Strings(keys)
+
+	var errs Errors
+
+	for _, key := range keys {
+		expr := compileExpression(o.selectValidators(rules[key], ""))
+
+		for _, target := range lookupMapPath(reflect.ValueOf(data), strings.Split(key, "."), "", "") {
+			if err := v.validateMapValue(target, expr); err != nil {
+				if !o.CollectAll {
+					return err
+				}
+
+				errs = errs.append(err)
+			}
+		}
+	}
+
+	return errs.errorOrNil()
+}
+
+// mapTarget is a value found by a path of Map rules, the value is invalid if it is missing.
+type mapTarget struct {
+	name  string
+	path  string
+	value reflect.Value
+}
+
+// nolint:gochecknoglobals
+var (
+	// nilPointer stands for a missing value checked by nil validators.
+	nilPointer = reflect.Zero(reflect.TypeOf((*interface{})(nil)))
+
+	errMissingValue = errors.New("the value is missing")
+)
+
+// lookupMapPath finds values by path segments, a star segment selects all elements.
+func lookupMapPath(value reflect.Value, segments []string, name, path string) []mapTarget {
+	value = indirectValue(value)
+
+	if len(segments) == 0 {
+		return []mapTarget{{name: name, path: path, value: value}}
+	}
+
+	segment, rest := segments[0], segments[1:]
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		if segment != "*" {
+			return lookupMapPath(value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key())),
+				rest, segment, joinPath(path, segment))
+		}
+
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		var targets []mapTarget
+		for _, key := range keys {
+			targets = append(targets, lookupMapPath(value.MapIndex(key), rest, key.String(), joinPath(path, key.String()))...)
+		}
+
+		return targets
+	case reflect.Slice, reflect.Array:
+		if segment != "*" {
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= value.Len() {
+				break
+			}
+
+			return lookupMapPath(value.Index(i), rest, name, joinPath(path, fmt.Sprintf("[%d]", i)))
+		}
+
+		var targets []mapTarget
+		for i := 0; i < value.Len(); i++ {
+			targets = append(targets, lookupMapPath(value.Index(i), rest, name, joinPath(path, fmt.Sprintf("[%d]", i)))...)
+		}
+
+		return targets
+	}
+
+	if segment == "*" {
+		return nil
+	}
+
+	// the value is missing, the path is reported as written in the rule
+	for _, segment := range segments {
+		name, path = segment, joinPath(path, segment)
+	}
+
+	return []mapTarget{{name: name, path: path}}
+}
+
+// validateMapElem validates a key or an element of a value found by a path of Map rules.
+func (v *validation) validateMapElem(target mapTarget, expr *expression) error {
+	if err := v.ctx.Err(); err != nil {
+		return err
+	}
+
+	return v.validateMapValue(target, expr)
+}
+
+// validateMapValue validates a value found by a path of Map rules.
+func (v *validation) validateMapValue(target mapTarget, expr *expression) error {
+	if expr.err != nil {
+		return withPath(setFieldName(expr.err, target.name), target.path)
+	}
+
+	if expr.guard != nil {
+		return withPath(ErrorSyntax{
+			fieldName:  target.name,
+			expression: expr.validators,
+			near:       string(If),
+			comment:    "conditions are not supported by Map",
+		}, target.path)
+	}
+
+	present := target.value.IsValid()
+
+	// nil validators check if the value is present
+	pointer := nilPointer
+	if present {
+		pointer = reflect.New(target.value.Type())
+		pointer.Elem().Set(target.value)
+	}
+
+	var err ErrorField
+
+	for _, validatorsAnd := range expr.validatorsOr {
+		// every alternative starts without errors, validators skipped for a missing value pass
+		err = nil
+
+		for _, validator := range validatorsAnd {
+			switch validatorFunc, ok := v.lookupValidator(validator.Type); {
+			case validator.Type == Nil:
+				err = validateNil(pointer, validator.Value)
+			case !ok:
+				err = ErrorSyntax{
+					expression: string(validator.Type),
+					near:       expr.validators,
+					comment:    "could not find a validator",
+				}
+			case present:
+				err = validatorFunc(target.value, validator.Value)
+			}
+
+			if err != nil {
+				err = setFieldName(err, target.name)
+				break
+			}
+		}
+
+		if err == nil {
+			break
+		}
+	}
+
+	if ev, ok := err.(ErrorValidation); ok && !present {
+		// the placeholder of a missing value is not reported
+		ev.fieldValue, ev.err = reflect.Value{}, errMissingValue
+		err = ev
+	}
+
+	var errs Errors
+
+	if err != nil {
+		err = v.setMessage(err, "")
+
+		if !v.CollectAll {
+			return withPath(err, target.path)
+		}
+
+		errs = errs.append(withPath(err, target.path))
+	}
+
+	if !present {
+		return errs.errorOrNil()
+	}
+
+	// Dive into keys and elements, missing elements are checked by nil validators too
+	value := target.value
+
+	switch value.Kind() {
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return mapKeySegment(keys[i]) < mapKeySegment(keys[j]) })
+
+		for _, key := range keys {
+			path := joinPath(target.path, mapKeySegment(key))
+
+			if err := v.validateMapElem(mapTarget{name: target.name, path: path, value: key}, expr.key); err != nil {
+				if !v.CollectAll {
+					return err
+				}
+
+				errs = errs.append(err)
+			}
+
+			elem := mapTarget{name: target.name, path: path, value: indirectValue(value.MapIndex(key))}
+			if err := v.validateMapElem(elem, expr.elem); err != nil {
+				if !v.CollectAll {
+					return err
+				}
+
+				errs = errs.append(err)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			path := joinPath(target.path, fmt.Sprintf("[%d]", i))
+
+			elem := mapTarget{name: target.name, path: path, value: indirectValue(value.Index(i))}
+			if err := v.validateMapElem(elem, expr.elem); err != nil {
+				if !v.CollectAll {
+					return err
+				}
+
+				errs = errs.append(err)
+			}
+		}
+	default:
+		if len(expr.elems) > 0 {
+			return withPath(ErrorSyntax{
+				fieldName:  target.name,
+				expression: expr.elems,
+				comment:    "unexpexted expression",
+			}, target.path)
+		}
+	}
+
+	if value.Kind() != reflect.Map && len(expr.keys) > 0 {
+		return withPath(ErrorSyntax{
+			fieldName:  target.name,
+			expression: expr.keys,
+			comment:    "unexpexted expression",
+		}, target.path)
+	}
+
+	return errs.errorOrNil()
+}
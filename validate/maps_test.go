@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, s string) map[string]interface{} {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestMap(t *testing.T) {
+	rules := map[string]string{
+		"name":         "nil=false & empty=false",
+		"age":          "gte=18 & lte=130",
+		"email":        "format=email",
+		"address.zip":  "format=numeric & eq=6",
+		"items":        "nil=false & empty=false > nil=false",
+		"items.*.qty":  "nil=false & gte=1",
+		"items.*.tags": "unique > empty=false",
+		"labels":       "[runes_lte=8] > empty=false",
+	}
+
+	valid := decodeJSON(t, `{
+		"name": "order",
+		"age": 30,
+		"address": {"zip": "100000"},
+		"items": [{"qty": 1, "tags": ["a", "b"]}, {"qty": 2}],
+		"labels": {"env": "prod"}
+	}`)
+
+	if err := Map(valid, rules); err != nil {
+		t.Errorf("Map returns %v", err)
+	}
+
+	// validators skipped for a missing value pass their branch
+	if err := Map(valid, map[string]string{"nickname": "nil=false | gte=1"}); err != nil {
+		t.Errorf("Map returns %v", err)
+	}
+
+	invalid := []struct {
+		data     string
+		expected string
+	}{
+		{`{"age": 30, "items": [{"qty": 1}]}`, "name"},
+		{`{"name": null, "items": [{"qty": 1}]}`, "name"},
+		{`{"name": "order", "age": 10, "items": [{"qty": 1}]}`, "age"},
+		{`{"name": "order", "email": "order", "items": [{"qty": 1}]}`, "email"},
+		{`{"name": "order", "address": {"zip": "1000"}, "items": [{"qty": 1}]}`, "address.zip"},
+		{`{"name": "order", "items": []}`, "items"},
+		{`{"name": "order", "items": [{"qty": 1}, null]}`, "items[1]"},
+		{`{"name": "order", "items": [{"qty": 1}, {"qty": 0}]}`, "items[1].qty"},
+		{`{"name": "order", "items": [{"qty": 1}, {}]}`, "items[1].qty"},
+		{`{"name": "order", "items": [{"qty": 1, "tags": ["a", "a"]}]}`, "items[0].tags"},
+		{`{"name": "order", "items": [{"qty": 1, "tags": ["a", ""]}]}`, "items[0].tags[1]"},
+		{`{"name": "order", "items": [{"qty": 1}], "labels": {"env": ""}}`, `labels["env"]`},
+	}
+
+	for _, c := range invalid {
+		err := Map(decodeJSON(t, c.data), rules)
+		if err == nil {
+			t.Errorf("Map does not validate %s", c.data)
+			continue
+		}
+
+		if path := err.(ErrorField).FieldPath(); path != c.expected {
+			t.Errorf("Map reports %q for %s, expected %q: %v", path, c.data, c.expected, err)
+		}
+	}
+}
+
+func TestMapCollectAll(t *testing.T) {
+	data := decodeJSON(t, `{"items": [{"qty": 0}, {"qty": 1}, {"qty": -1}]}`)
+
+	err := Map(data, map[string]string{
+		"name":        "nil=false",
+		"items.*.qty": "gte=1",
+	}, CollectAll())
+
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("Map returns %v", err)
+	}
+
+	for i, expected := range []string{"items[0].qty", "items[2].qty", "name"} {
+		if path := errs[i].FieldPath(); path != expected {
+			t.Errorf("Map reports %q, expected %q", path, expected)
+		}
+	}
+}
+
+func TestMapSyntax(t *testing.T) {
+	if err, ok := Map(map[string]interface{}{}, map[string]string{"name": "unknown=1"}).(ErrorSyntax); !ok {
+		t.Errorf("Map does not report a syntax error, returns %v", err)
+	}
+
+	err := Map(map[string]interface{}{"address": map[string]interface{}{}},
+		map[string]string{"address.zip": "nil=false"})
+	expected := `Validation error in field "address.zip" using validator "nil=false": the value is missing`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Map returns %v, expected %q", err, expected)
+	}
+
+	option, err := New(Locale(LocaleEn))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = option.Map(map[string]interface{}{}, map[string]string{"name": "nil=false"})
+	if err == nil || err.Error() != "name is required" {
+		t.Errorf("Map returns %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package validate
+
+import (
+	"strings"
+)
+
+// Messages is a message catalog of a locale.
+// Messages are templates with placeholders {field}, {value} and {param},
+// e.g. "{field} must be greater than {param}".
+// A message for a validator with a value, e.g. "empty=false", takes precedence over a message for the validator.
+type Messages struct {
+	// Default is used when there is no message for a validator.
+	Default string
+	// Validators contains messages by validator type, e.g. "gte" or "empty=false".
+	Validators map[Type]string
+	// Formats contains messages by format type of the format validator, e.g. "email".
+	Formats map[FormatType]string
+}
+
+// Following locales are available.
+const (
+	LocaleEn   = "en"
+	LocaleZhCN = "zh-CN"
+)
+
+// RegisterMessages registers a message catalog of a locale globally, it replaces the catalog registered before.
+func RegisterMessages(locale string, messages Messages) {
+	globalRegistry.registerMessages(locale, messages)
+}
+
+// RegisterMessages registers a message catalog of a locale only for the option.
+func (o *Option) RegisterMessages(locale string, messages Messages) {
+	o.registry.registerMessages(locale, messages)
+}
+
+// Locale defines the locale of error messages, e.g. "en" or "zh-CN".
+// Without a locale errors have the default messages in English.
+func Locale(locale string) OptionFn { return func(o *Option) { o.Locale = locale } }
+
+// MessageTagName defines the tag name for custom error messages of a field.
+func MessageTagName(tagName string) OptionFn { return func(o *Option) { o.MessageTagName = tagName } }
+
+func (r *registry) registerMessages(locale string, messages Messages) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.messages == nil {
+		r.messages = make(map[string]Messages)
+	}
+
+	r.messages[locale] = messages
+}
+
+func (r *registry) lookupMessages(locale string) (Messages, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	messages, ok := r.messages[locale]
+
+	return messages, ok
+}
+
+// lookupMessages finds a catalog of the locale, then a catalog of the language of the locale,
+// e.g. "zh" for "zh-CN". Catalogs of the option take precedence over global ones.
+func (o *Option) lookupMessages(locale string) (Messages, bool) {
+	locales := []string{locale}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locales = append(locales, locale[:i])
+	}
+
+	for _, l := range locales {
+		if messages, ok := o.registry.lookupMessages(l); ok {
+			return messages, true
+		}
+
+		if messages, ok := globalRegistry.lookupMessages(l); ok {
+			return messages, true
+		}
+	}
+
+	return Messages{}, false
+}
+
+// message finds a message template of a validation error in the catalog of the option locale.
+func (o *Option) message(e ErrorValidation) string {
+	if o.Locale == "" {
+		return ""
+	}
+
+	messages, ok := o.lookupMessages(o.Locale)
+	if !ok {
+		return ""
+	}
+
+	if e.validatorType == Format {
+		if message, ok := messages.Formats[FormatType(e.validatorValue)]; ok {
+			return message
+		}
+	}
+
+	if message, ok := messages.Validators[e.validatorType+"="+Type(e.validatorValue)]; ok {
+		return message
+	}
+
+	if message, ok := messages.Validators[e.validatorType]; ok {
+		return message
+	}
+
+	return messages.Default
+}
+
+// setMessage sets a message template of a validation error.
+// A custom message of the field takes precedence over the catalog of the option locale.
+func (o *Option) setMessage(err ErrorField, message string) ErrorField {
+	e, ok := err.(ErrorValidation)
+	if !ok {
+		return err
+	}
+
+	if message == "" {
+		message = o.message(e)
+	}
+
+	e.message = message
+
+	return e
+}
+
+// renderMessage replaces placeholders of a message template.
+func renderMessage(message string, e ErrorValidation) string {
+	fieldName := e.fieldPath
+	if fieldName == "" {
+		fieldName = e.fieldName
+	}
+
+	value := ""
+	if e.fieldValue.IsValid() {
+		value = formatValue(e.fieldValue)
+	}
+
+	return strings.NewReplacer("{field}", fieldName, "{value}", value, "{param}", e.validatorValue).Replace(message)
+}
+
+// nolint:gochecknoglobals,lll
+var (
+	messagesEn = Messages{
+		Default: "{field} is invalid",
+		Validators: map[Type]string{
+			Eq:                "{field} must be equal to {param}",
+			Ne:                "{field} must not be equal to {param}",
+			Gt:                "{field} must be greater than {param}",
+			Lt:                "{field} must be less than {param}",
+			Gte:               "{field} must be greater than or equal to {param}",
+			Lte:               "{field} must be less than or equal to {param}",
+			RunesEq:           "{field} must contain exactly {param} characters",
+			RunesGt:           "{field} must contain more than {param} characters",
+			RunesLt:           "{field} must contain fewer than {param} characters",
+			RunesGte:          "{field} must contain at least {param} characters",
+			RunesLte:          "{field} must contain at most {param} characters",
+			Prefix:            "{field} must start with {param}",
+			Suffix:            "{field} must end with {param}",
+			Contains:          "{field} must contain {param}",
+			Excludes:          "{field} must not contain {param}",
+			Regex:             "{field} must match the {param} pattern",
+			Empty + "=true":   "{field} must be empty",
+			Empty + "=false":  "{field} must not be empty",
+			Nil + "=true":     "{field} must not be set",
+			Nil + "=false":    "{field} is required",
+			Enum:              "{field} must be one of {param}",
+			Format:            "{field} must be in {param} format",
+			EqField:           "{field} must be equal to {param}",
+			NeField:           "{field} must not be equal to {param}",
+			GtField:           "{field} must be greater than {param}",
+			GteField:          "{field} must be greater than or equal to {param}",
+			LtField:           "{field} must be less than {param}",
+			LteField:          "{field} must be less than or equal to {param}",
+			RequiredIf:        "{field} is required",
+			RequiredUnless:    "{field} is required",
+			Datetime:          "{field} must be a date and time in {param} layout",
+			Unique:            "{field} must contain unique elements",
+			Sorted:            "{field} must be sorted",
+			DistinctValues:    "{field} must contain unique values",
+			OneOfRequired:     "one of {param} is required",
+			AllOrNone:         "all or none of {param} must be set",
+			MutuallyExclusive: "only one of {param} could be set",
+			AtLeast:           "at least {param} must be set",
+			Postcode:          "{field} must be a valid postcode of {param}",
+			PostcodeField:     "{field} must be a valid postcode of the country",
+		},
+		Formats: map[FormatType]string{
+			FormatEmail:          "{field} must be a valid email address",
+			FormatURL:            "{field} must be a valid URL",
+			FormatURI:            "{field} must be a valid URI",
+			FormatUUID:           "{field} must be a valid UUID",
+			FormatIPv4:           "{field} must be a valid IPv4 address",
+			FormatIPv6:           "{field} must be a valid IPv6 address",
+			FormatIP:             "{field} must be a valid IP address",
+			FormatNumeric:        "{field} must contain only digits",
+			FormatAlpha:          "{field} must contain only letters",
+			FormatAlnum:          "{field} must contain only letters and digits",
+			FormatHostnameRFC952: "{field} must be a valid hostname",
+			FormatRFC3339:        "{field} must be a valid RFC3339 timestamp",
+			FormatDate:           "{field} must be a valid date",
+			FormatTimezone:       "{field} must be a valid time zone",
+			FormatE164:           "{field} must be a valid phone number",
+			FormatCNMobile:       "{field} must be a valid mobile number",
+			FormatCNID:           "{field} must be a valid resident ID number",
+			FormatIBAN:           "{field} must be a valid IBAN",
+			FormatCreditCard:     "{field} must be a valid credit card number",
+			FormatSemver:         "{field} must be a valid semantic version",
+			FormatCron:           "{field} must be a valid cron expression",
+			FormatISO3166Alpha2:  "{field} must be a valid country code",
+			FormatISO3166Alpha3:  "{field} must be a valid country code",
+			FormatISO4217:        "{field} must be a valid currency code",
+		},
+	}
+
+	messagesZhCN = Messages{
+		Default: "{field}校验失败",
+		Validators: map[Type]string{
+			Eq:                "{field}必须等于{param}",
+			Ne:                "{field}不能等于{param}",
+			Gt:                "{field}必须大于{param}",
+			Lt:                "{field}必须小于{param}",
+			Gte:               "{field}必须大于或等于{param}",
+			Lte:               "{field}必须小于或等于{param}",
+			RunesEq:           "{field}必须是{param}个字符",
+			RunesGt:           "{field}必须多于{param}个字符",
+			RunesLt:           "{field}必须少于{param}个字符",
+			RunesGte:          "{field}至少需要{param}个字符",
+			RunesLte:          "{field}最多只能有{param}个字符",
+			Prefix:            "{field}必须以{param}开头",
+			Suffix:            "{field}必须以{param}结尾",
+			Contains:          "{field}必须包含{param}",
+			Excludes:          "{field}不能包含{param}",
+			Regex:             "{field}必须符合{param}格式",
+			Empty + "=true":   "{field}必须为空",
+			Empty + "=false":  "{field}不能为空",
+			Nil + "=true":     "{field}不能设置",
+			Nil + "=false":    "{field}为必填字段",
+			Enum:              "{field}必须是[{param}]中的一个",
+			Format:            "{field}必须是{param}格式",
+			EqField:           "{field}必须等于{param}",
+			NeField:           "{field}不能等于{param}",
+			GtField:           "{field}必须大于{param}",
+			GteField:          "{field}必须大于或等于{param}",
+			LtField:           "{field}必须小于{param}",
+			LteField:          "{field}必须小于或等于{param}",
+			RequiredIf:        "{field}为必填字段",
+			RequiredUnless:    "{field}为必填字段",
+			Datetime:          "{field}必须是{param}格式的日期时间",
+			Unique:            "{field}不能包含重复的元素",
+			Sorted:            "{field}必须是有序的",
+			DistinctValues:    "{field}不能包含重复的值",
+			OneOfRequired:     "{param}至少需要填写一个",
+			AllOrNone:         "{param}必须全部填写或者全部不填",
+			MutuallyExclusive: "{param}最多只能填写一个",
+			AtLeast:           "至少需要填写{param}",
+			Postcode:          "{field}必须是一个有效的{param}邮政编码",
+			PostcodeField:     "{field}必须是一个有效的所在国家的邮政编码",
+		},
+		Formats: map[FormatType]string{
+			FormatEmail:          "{field}必须是一个有效的邮箱",
+			FormatURL:            "{field}必须是一个有效的URL",
+			FormatURI:            "{field}必须是一个有效的URI",
+			FormatUUID:           "{field}必须是一个有效的UUID",
+			FormatIPv4:           "{field}必须是一个有效的IPv4地址",
+			FormatIPv6:           "{field}必须是一个有效的IPv6地址",
+			FormatIP:             "{field}必须是一个有效的IP地址",
+			FormatNumeric:        "{field}只能包含数字",
+			FormatAlpha:          "{field}只能包含字母",
+			FormatAlnum:          "{field}只能包含字母和数字",
+			FormatHostnameRFC952: "{field}必须是一个有效的主机名",
+			FormatRFC3339:        "{field}必须是一个有效的RFC3339时间",
+			FormatDate:           "{field}必须是一个有效的日期",
+			FormatTimezone:       "{field}必须是一个有效的时区",
+			FormatE164:           "{field}必须是一个有效的电话号码",
+			FormatCNMobile:       "{field}必须是一个有效的手机号码",
+			FormatCNID:           "{field}必须是一个有效的身份证号码",
+			FormatIBAN:           "{field}必须是一个有效的IBAN",
+			FormatCreditCard:     "{field}必须是一个有效的信用卡号",
+			FormatSemver:         "{field}必须是一个有效的语义化版本",
+			FormatCron:           "{field}必须是一个有效的cron表达式",
+			FormatISO3166Alpha2:  "{field}必须是一个有效的国家代码",
+			FormatISO3166Alpha3:  "{field}必须是一个有效的国家代码",
+			FormatISO4217:        "{field}必须是一个有效的货币代码",
+		},
+	}
+)
+
+// nolint:gochecknoinits
+func init() {
+	RegisterMessages(LocaleEn, messagesEn)
+	RegisterMessages(LocaleZhCN, messagesZhCN)
+}
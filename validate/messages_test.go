@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestMessages(t *testing.T) {
+	type User struct {
+		Name  string   `validate:"gte=3"`
+		Email string   `validate:"format=email"`
+		Tags  []string `validate:"> empty=false" msg:"{field} contains an empty tag"`
+		Age   int      `validate:"gte=18" msg:"{field} is {value}, should be at least {param}"`
+		Nick  string   `validate:"runes_gte=3 & prefix=@"`
+	}
+
+	tests := []struct {
+		user     User
+		options  []OptionFn
+		expected string
+	}{
+		{User{Name: "a"}, []OptionFn{Locale(LocaleEn)}, "Name must be greater than or equal to 3"},
+		{User{Name: "a"}, []OptionFn{Locale(LocaleZhCN)}, "Name必须大于或等于3"},
+		{User{Name: "a"}, []OptionFn{Locale("zh")}, "Validation error in field \"Name\" of type \"string\" using validator \"gte=3\""},
+		{User{Name: "abc", Email: "a"}, []OptionFn{Locale("en-US")}, "Email must be a valid email address"},
+		{User{Name: "abc", Email: "a"}, []OptionFn{Locale(LocaleZhCN)}, "Email必须是一个有效的邮箱"},
+		{User{Name: "abc", Email: "a@b.c", Tags: []string{"a", ""}}, nil, "Tags[1] contains an empty tag"},
+		{User{Name: "abc", Email: "a@b.c", Age: 3}, []OptionFn{Locale(LocaleZhCN)}, "Age is 3, should be at least 18"},
+		{User{Name: "abc", Email: "a@b.c", Age: 18, Nick: "@李"}, []OptionFn{Locale(LocaleEn)},
+			"Nick must contain at least 3 characters"},
+		{User{Name: "abc", Email: "a@b.c", Age: 18, Nick: "@李"}, []OptionFn{Locale(LocaleZhCN)}, "Nick至少需要3个字符"},
+		{User{Name: "abc", Email: "a@b.c", Age: 18, Nick: "李小龙"}, []OptionFn{Locale(LocaleEn)},
+			"Nick must start with @"},
+		{User{Name: "abc", Email: "a@b.c", Age: 18, Nick: "李小龙"}, []OptionFn{Locale(LocaleZhCN)}, "Nick必须以@开头"},
+	}
+
+	for _, test := range tests {
+		if err := Validate(test.user, test.options...); err == nil || err.Error() != test.expected {
+			t.Errorf("validate returns message %q, expected %q", err, test.expected)
+		}
+	}
+}
+
+func TestRegisterMessages(t *testing.T) {
+	option, err := New(Locale("de"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	option.RegisterMessages("de", Messages{
+		Default:    "{field} ist ungültig",
+		Validators: map[Type]string{Empty + "=false": "{field} darf nicht leer sein"},
+	})
+
+	if err := option.Validate(struct {
+		Name string `validate:"empty=false"`
+	}{}); err == nil || err.Error() != "Name darf nicht leer sein" {
+		t.Errorf("validate returns message %q", err)
+	}
+
+	if err := option.Validate(struct {
+		Name string `validate:"eq=1"`
+	}{}); err == nil || err.Error() != "Name ist ungültig" {
+		t.Errorf("validate returns message %q", err)
+	}
+}
@@ -0,0 +1,310 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ModifierType is used for modifier type definitions.
+type ModifierType string
+
+// Following modifiers are available, they change strings in place before validators run.
+// Modifiers are applied in order, e.g. `mod:"trim & lower"`. Like in validate tags,
+// ">" applies modifiers to elements of slices, values of maps and targets of pointers, e.g. `mod:"> trim"`.
+const (
+	// ModTrim removes leading and trailing white spaces.
+	ModTrim ModifierType = "trim"
+
+	// ModLower converts letters to lower case.
+	ModLower = "lower"
+
+	// ModUpper converts letters to upper case.
+	ModUpper = "upper"
+
+	// ModTitle converts the first letter of each word to upper case.
+	ModTitle = "title"
+
+	// ModSquashSpaces trims a string and replaces each run of white spaces with a single space.
+	ModSquashSpaces = "squash_spaces"
+
+	// ModDigitsOnly removes all characters except digits 0-9.
+	ModDigitsOnly = "digits_only"
+)
+
+// ModifierFunc is a modifier func, it returns the modified string.
+type ModifierFunc func(value string) string
+
+// nolint:gochecknoglobals
+var modifierTypeMap = map[ModifierType]ModifierFunc{
+	ModTrim:         strings.TrimSpace,
+	ModLower:        strings.ToLower,
+	ModUpper:        strings.ToUpper,
+	ModTitle:        titleCase,
+	ModSquashSpaces: func(s string) string { return strings.Join(strings.Fields(s), " ") },
+	ModDigitsOnly:   func(s string) string { return strings.Map(digitOnly, s) },
+}
+
+// ModTagName defines the tag name for modifiers.
+func ModTagName(tagName string) OptionFn { return func(o *Option) { o.ModTagName = tagName } }
+
+// RegisterModifier registers a custom modifier globally.
+// The modifier could be used in tags like a built-in one, e.g. `mod:"trim & strip_dashes"`.
+func RegisterModifier(name string, fn ModifierFunc) {
+	globalRegistry.registerModifier(ModifierType(name), fn)
+}
+
+// RegisterModifier registers a custom modifier only for the option.
+func (o *Option) RegisterModifier(name string, fn ModifierFunc) {
+	o.registry.registerModifier(ModifierType(name), fn)
+}
+
+// lookupModifier finds a modifier registered for the option, then a global one, then a built-in one.
+func (o *Option) lookupModifier(name ModifierType) (ModifierFunc, bool) {
+	if fn, ok := o.registry.modifier(name); ok {
+		return fn, true
+	}
+
+	if fn, ok := globalRegistry.modifier(name); ok {
+		return fn, true
+	}
+
+	fn, ok := modifierTypeMap[name]
+
+	return fn, ok
+}
+
+// titleCase converts the first letter of each word to title case, other letters are left as is.
+func titleCase(s string) string {
+	runes := []rune(s)
+
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToTitle(r)
+		}
+	}
+
+	return string(runes)
+}
+
+func digitOnly(r rune) rune {
+	if r >= '0' && r <= '9' {
+		return r
+	}
+
+	return -1
+}
+
+// modifyField applies modifiers to a value in place.
+// set stores a modified value, it is used for values which could not be set directly, e.g. values of maps.
+func (v *validation) modifyField(value reflect.Value, fieldName string, expr *expression,
+	parents []reflect.Value, set func(reflect.Value)) error {
+	if expr == emptyExpression {
+		return nil
+	}
+
+	if err := v.checkModifiers(fieldName, expr); err != nil {
+		return err
+	}
+
+	if expr.guard != nil {
+		ok, err := expr.guard.eval(parents)
+		if err != nil {
+			return setFieldName(err, fieldName)
+		}
+
+		if !ok {
+			return nil
+		}
+
+		return v.modifyField(value, fieldName, expr.then, parents, set)
+	}
+
+	// Modify the dynamic value of an interface, e.g. an element of []interface{},
+	// modifiers skip dynamic values which are not strings
+	dynamic := value.Kind() == reflect.Interface
+	if dynamic {
+		if value.IsNil() {
+			return nil
+		}
+
+		if value.CanSet() {
+			iface := value
+			set = func(x reflect.Value) { iface.Set(x) }
+		}
+
+		value = value.Elem()
+	}
+
+	if len(expr.validatorsOr) > 0 && (!dynamic || value.Kind() == reflect.String) {
+		if value.Kind() != reflect.String {
+			return ErrorSyntax{
+				fieldName:  fieldName,
+				expression: expr.validators,
+				near:       value.Kind().String(),
+				comment:    "modifiers work with strings only",
+			}
+		}
+
+		if err := v.modifyString(value, set, expr.validatorsOr[0]); err != nil {
+			return setFieldName(err, fieldName)
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			key, m := key, value
+
+			// values of maps obtained by unexported fields could not be set
+			var setValue func(reflect.Value)
+			if m.CanInterface() {
+				setValue = func(x reflect.Value) { m.SetMapIndex(key, x) }
+			}
+
+			if err := v.modifyField(value.MapIndex(key), fieldName, expr.elem, parents, setValue); err != nil {
+				return withPath(err, mapKeySegment(key))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.modifyField(value.Index(i), fieldName, expr.elem, parents, nil); err != nil {
+				return withPath(err, fmt.Sprintf("[%d]", i))
+			}
+		}
+	case reflect.Ptr:
+		if !value.IsNil() {
+			return v.modifyField(value.Elem(), fieldName, expr.elem, parents, nil)
+		}
+	default:
+		if expr.elems != "" {
+			return ErrorSyntax{fieldName: fieldName, expression: expr.elems, comment: "unexpexted expression"}
+		}
+	}
+
+	return nil
+}
+
+// modifyString applies modifiers to a string, it reports an ErrorSyntax if the string could not be set,
+// whether modifiers change it or not.
+func (v *validation) modifyString(value reflect.Value, set func(reflect.Value), modifiers []validator) ErrorField {
+	if !value.CanSet() && set == nil {
+		names := make([]string, len(modifiers))
+		for i, modifier := range modifiers {
+			names[i] = string(modifier.Type)
+		}
+
+		return ErrorSyntax{
+			expression: strings.Join(names, " & "),
+			near:       value.Type().String(),
+			comment:    "modifiers need a pointer to modify the value, e.g. Validate(&v)",
+		}
+	}
+
+	s := value.String()
+
+	for _, modifier := range modifiers {
+		fn, ok := v.lookupModifier(ModifierType(modifier.Type))
+		if !ok {
+			return ErrorSyntax{expression: string(modifier.Type), comment: "could not find a modifier"}
+		}
+
+		s = fn(s)
+	}
+
+	if s == value.String() {
+		return nil
+	}
+
+	modified := reflect.ValueOf(s).Convert(value.Type())
+
+	if value.CanSet() {
+		value.Set(modified)
+	} else {
+		set(modified)
+	}
+
+	return nil
+}
+
+// checkModifiers checks the syntax of a modifiers expression, which does not depend on types.
+func (o *Option) checkModifiers(fieldName string, expr *expression) error {
+	if expr.err != nil {
+		return setFieldName(expr.err, fieldName)
+	}
+
+	if len(expr.validatorsOr) > 1 {
+		return ErrorSyntax{fieldName: fieldName, expression: expr.validators, near: "|", comment: "modifiers could not be alternated"}
+	}
+
+	if expr.keys != "" {
+		return ErrorSyntax{fieldName: fieldName, expression: expr.keys, comment: "modifiers of map keys are not supported"}
+	}
+
+	for _, validators := range expr.validatorsOr {
+		for _, modifier := range validators {
+			if _, ok := o.lookupModifier(ModifierType(modifier.Type)); !ok {
+				return ErrorSyntax{
+					fieldName:  fieldName,
+					expression: string(modifier.Type),
+					near:       expr.validators,
+					comment:    "could not find a modifier",
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// compileModifiers checks a modifiers expression against a type.
+func (o *Option) compileModifiers(typ reflect.Type, fieldName string, expr *expression, parents []reflect.Type) error {
+	if expr == emptyExpression {
+		return nil
+	}
+
+	if err := o.checkModifiers(fieldName, expr); err != nil {
+		return err
+	}
+
+	if expr.guard != nil {
+		for _, field := range expr.guard.fields {
+			if !resolveFieldType(parents, field) {
+				return ErrorSyntax{
+					fieldName:  fieldName,
+					expression: expr.guard.text,
+					near:       field,
+					comment:    "could not find a field",
+				}
+			}
+		}
+
+		return o.compileModifiers(typ, fieldName, expr.then, parents)
+	}
+
+	kind := typ.Kind()
+
+	if len(expr.validatorsOr) > 0 && kind != reflect.String && kind != reflect.Interface {
+		return ErrorSyntax{
+			fieldName:  fieldName,
+			expression: expr.validators,
+			near:       kind.String(),
+			comment:    "modifiers work with strings only",
+		}
+	}
+
+	switch kind {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr:
+		return o.compileModifiers(typ.Elem(), fieldName, expr.elem, parents)
+	case reflect.Interface:
+		// the dynamic type is checked when modifiers are applied
+		return nil
+	}
+
+	if expr.elems != "" {
+		return ErrorSyntax{fieldName: fieldName, expression: expr.elems, comment: "unexpexted expression"}
+	}
+
+	return nil
+}
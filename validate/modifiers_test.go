@@ -0,0 +1,151 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type signup struct {
+	Email    string            `mod:"trim & lower" validate:"format=email"`
+	Name     string            `mod:"squash_spaces & title"`
+	Country  string            `mod:"trim & upper" validate:"format=iso3166_alpha2"`
+	Phone    *string           `mod:"> digits_only" validate:"> format=numeric"`
+	Tags     []string          `mod:"> trim & lower"`
+	Labels   map[string]string `mod:"> trim"`
+	Extra    []interface{}     `mod:"> trim"`
+	Password string
+	Confirm  string `mod:"trim" validate:"eqfield=Password"`
+}
+
+func TestModifiers(t *testing.T) {
+	phone := "138-0013-8000"
+	s := signup{
+		Email:    "  Bingoo@Example.COM ",
+		Name:     "  bingoo   huang ",
+		Country:  " cn",
+		Phone:    &phone,
+		Tags:     []string{" Go ", "RUST"},
+		Labels:   map[string]string{"env": " prod "},
+		Extra:    []interface{}{" a ", 1},
+		Password: "secret",
+		Confirm:  " secret ",
+	}
+
+	if err := Validate(&s); err != nil {
+		t.Fatalf("modifiers are not applied: %v", err)
+	}
+
+	expected := signup{
+		Email:    "bingoo@example.com",
+		Name:     "Bingoo Huang",
+		Country:  "CN",
+		Phone:    &phone,
+		Tags:     []string{"go", "rust"},
+		Labels:   map[string]string{"env": "prod"},
+		Extra:    []interface{}{"a", 1},
+		Password: "secret",
+		Confirm:  "secret",
+	}
+
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("modifiers modify %+v, expected %+v", s, expected)
+	}
+
+	if phone != "13800138000" {
+		t.Errorf("modifiers modify %q, expected %q", phone, "13800138000")
+	}
+}
+
+func TestModifiersNotAddressable(t *testing.T) {
+	// fields of a struct passed by value could not be modified, whether modifiers change them or not
+	for _, s := range []signup{{Email: " A@B.COM ", Country: "CN"}, {Email: "a@b.c", Country: "CN"}} {
+		err := Validate(s)
+		if _, ok := err.(ErrorSyntax); !ok || !strings.Contains(err.Error(), "modifiers need a pointer") {
+			t.Errorf("modifiers of a struct passed by value report %v, expected a syntax error", err)
+		}
+	}
+
+	// values of maps of unexported fields could not be set
+	s := struct {
+		tags map[string]string `mod:"> trim"`
+	}{tags: map[string]string{"a": " b "}}
+
+	err := Validate(&s)
+	if _, ok := err.(ErrorSyntax); !ok || !strings.Contains(err.Error(), "modifiers need a pointer") {
+		t.Errorf("modifiers of an unexported map report %v, expected a syntax error", err)
+	}
+
+	if s.tags["a"] != " b " {
+		t.Errorf("modifiers modify an unexported map %q", s.tags["a"])
+	}
+}
+
+func TestCustomModifiers(t *testing.T) {
+	RegisterModifier("strip_dashes", func(s string) string { return strings.Replace(s, "-", "", -1) })
+
+	s := struct {
+		Code string `mod:"strip_dashes & upper"`
+	}{"ab-12-cd"}
+
+	if err := Validate(&s); err != nil || s.Code != "AB12CD" {
+		t.Errorf("custom modifier is not applied: %q, %v", s.Code, err)
+	}
+
+	option, _ := New(ModTagName("sanitize"))
+	option.RegisterModifier("reverse", func(s string) string {
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+
+		return string(r)
+	})
+
+	r := struct {
+		Text string `sanitize:"trim & reverse"`
+	}{" abc "}
+
+	if err := option.Validate(&r); err != nil || r.Text != "cba" {
+		t.Errorf("custom modifier is not applied: %q, %v", r.Text, err)
+	}
+
+	if _, ok := Validate(&r).(ErrorSyntax); ok {
+		t.Errorf("modifiers of an option are used globally")
+	}
+}
+
+func TestModifiersSyntax(t *testing.T) {
+	tests := []interface{}{
+		struct {
+			Name string `mod:"trim | lower"`
+		}{},
+		struct {
+			Name string `mod:"unknown"`
+		}{},
+		struct {
+			Age int `mod:"trim"`
+		}{},
+		struct {
+			Labels map[string]string `mod:"[trim]"`
+		}{},
+		struct {
+			Name string `mod:"> trim"`
+		}{},
+	}
+
+	for _, test := range tests {
+		if _, ok := Compile(test).(ErrorSyntax); !ok {
+			t.Errorf("modifiers of %T do not report a syntax error", test)
+		}
+
+		ptr := reflect.New(reflect.TypeOf(test))
+		if _, ok := Validate(ptr.Interface()).(ErrorSyntax); !ok {
+			t.Errorf("modifiers of %T do not report a syntax error", test)
+		}
+	}
+
+	if err := Compile(signup{}); err != nil {
+		t.Errorf("modifiers do not compile: %v", err)
+	}
+}
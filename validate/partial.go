@@ -0,0 +1,242 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PartialHooks makes Partial call custom validators (Validator and ValidatorWithContext)
+// of the element and the values on the way to the selected paths, which are skipped by default.
+func PartialHooks() OptionFn { return func(o *Option) { o.PartialHooks = true } }
+
+// Partial validates only the given paths of an element with the default option.
+// A path is a field path like reported in errors, e.g. "Name", "Address.City", "Items[1].Qty" or `Labels["env"]`.
+// A field name without an index below a slice, an array or a map selects the field of all elements, e.g. "Items.Qty".
+// Validators of the values on the way to the selected paths are skipped.
+func Partial(element interface{}, paths ...string) error {
+	option, err := createOption(nil)
+	if err != nil {
+		return err
+	}
+
+	return option.Partial(element, paths...)
+}
+
+// PartialMap validates only the fields present in a decoded JSON object, e.g. the body of a PATCH request.
+// Nested objects select their present fields, other values select the whole field.
+// Use FieldNameTag("json") to match the keys with JSON field names.
+func PartialMap(element interface{}, present map[string]interface{}, optionFns ...OptionFn) error {
+	option, err := createOption(optionFns)
+	if err != nil {
+		return err
+	}
+
+	return option.PartialMap(element, present)
+}
+
+// Partial validates only the given paths of an element with the option, see Partial function.
+func (o *Option) Partial(element interface{}, paths ...string) error {
+	return o.PartialContext(context.Background(), element, paths...)
+}
+
+// PartialMap validates only the present fields of an element with the option, see PartialMap function.
+func (o *Option) PartialMap(element interface{}, present map[string]interface{}) error {
+	return o.PartialContext(context.Background(), element, presentPaths("", present)...)
+}
+
+// PartialContext validates only the given paths of an element like Partial,
+// the context is used like in ValidateContext.
+func (o *Option) PartialContext(ctx context.Context, element interface{}, paths ...string) error {
+	if len(o.Groups) > 0 {
+		ctx = context.WithValue(ctx, groupsKey{}, o.Groups)
+	}
+
+	selected := &pathNode{}
+	for _, path := range paths {
+		selected.add(splitPath(path))
+	}
+
+	selected.spread()
+
+	v := &validation{Option: o, ctx: ctx}
+
+	return v.validateField(reflect.ValueOf(element), "", compileExpression(""), nil, "", selected)
+}
+
+// presentPaths converts keys of a decoded JSON object to paths.
+func presentPaths(prefix string, present map[string]interface{}) []string {
+	paths := make([]string, 0, len(present))
+
+	for key, value := range present {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			paths = append(paths, presentPaths(path, nested)...)
+		} else {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// pathNode is a tree of paths selected by Partial.
+// A nil node selects everything, a node without children selects nothing unless all is set.
+type pathNode struct {
+	all bool
+	// fields contains children by field names
+	fields map[string]*pathNode
+	// elems contains children by index segments, e.g. [1] or ["env"]
+	elems map[string]*pathNode
+	// rest selects fields of elements without their own index segments
+	rest *pathNode
+}
+
+// splitPath splits a path into segments, e.g. `Items[1].Labels["env"]` into Items, [1], Labels and ["env"].
+func splitPath(path string) []string {
+	var segments []string
+
+	for path != "" {
+		switch {
+		case path[0] == '.':
+			path = path[1:]
+		case path[0] == '[':
+			end := strings.Index(path, "]")
+			if strings.HasPrefix(path, `["`) {
+				// a quoted map key could contain ] itself
+				if quoted := strings.Index(path[2:], `"]`); quoted >= 0 {
+					end = quoted + 3
+				}
+			}
+
+			if end < 0 {
+				end = len(path) - 1
+			}
+
+			segments = append(segments, path[:end+1])
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+
+			segments = append(segments, path[:end])
+			path = path[end:]
+		}
+	}
+
+	return segments
+}
+
+// add adds a path given by segments to the tree.
+func (n *pathNode) add(segments []string) {
+	for _, segment := range segments {
+		if n.all {
+			return
+		}
+
+		n = n.childOf(segment)
+	}
+
+	n.selectAll()
+}
+
+// childOf returns the child of a segment, it creates the child if it does not exist.
+func (n *pathNode) childOf(segment string) *pathNode {
+	children := &n.fields
+	if strings.HasPrefix(segment, "[") {
+		children = &n.elems
+	}
+
+	if *children == nil {
+		*children = map[string]*pathNode{}
+	}
+
+	child, ok := (*children)[segment]
+	if !ok {
+		child = &pathNode{}
+		(*children)[segment] = child
+	}
+
+	return child
+}
+
+// selectAll makes the node select everything below it.
+func (n *pathNode) selectAll() {
+	n.all = true
+	n.fields, n.elems = nil, nil
+}
+
+// merge adds paths of another node to the node.
+func (n *pathNode) merge(other *pathNode) {
+	switch {
+	case n.all:
+		return
+	case other.all:
+		n.selectAll()
+		return
+	}
+
+	for segment, child := range other.fields {
+		n.childOf(segment).merge(child)
+	}
+
+	for segment, child := range other.elems {
+		n.childOf(segment).merge(child)
+	}
+}
+
+// spread merges fields selected for all elements into children of index segments
+// and prepares the rest node, it should be called after all paths are added.
+func (n *pathNode) spread() {
+	for _, child := range n.elems {
+		for name, field := range n.fields {
+			if !child.all {
+				child.childOf(name).merge(field)
+			}
+		}
+	}
+
+	// elements of elements, e.g. of [][]T, share the rest node
+	n.rest = &pathNode{fields: n.fields}
+	n.rest.rest = n.rest
+
+	for _, child := range n.fields {
+		child.spread()
+	}
+
+	for _, child := range n.elems {
+		child.spread()
+	}
+}
+
+// field returns the node selected for a field, or nil if the field is not selected.
+// Fields of embedded structs without a name are promoted, so they share the node.
+func (n *pathNode) field(name string) *pathNode {
+	if n == nil || n.all || name == "" {
+		return n
+	}
+
+	return n.fields[name]
+}
+
+// child returns the node selected for an element of a slice, an array or a map.
+func (n *pathNode) child(segment string) *pathNode {
+	if n == nil || n.all {
+		return n
+	}
+
+	if child, ok := n.elems[segment]; ok {
+		return child
+	}
+
+	return n.rest
+}
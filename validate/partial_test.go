@@ -0,0 +1,136 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type partialItem struct {
+	Code string `json:"code" validate:"empty=false"`
+	Qty  int    `json:"qty" validate:"gte=1"`
+}
+
+type partialAddress struct {
+	City    string `json:"city" validate:"empty=false"`
+	Country string `json:"country" validate:"runes_eq=2"`
+}
+
+type partialOrder struct {
+	Name    string            `json:"name" validate:"empty=false"`
+	Email   string            `json:"email" validate:"format=email"`
+	Address *partialAddress   `json:"address"`
+	Items   []partialItem     `json:"items" validate:"empty=false"`
+	Labels  map[string]string `json:"labels" validate:"> empty=false"`
+}
+
+func (o partialOrder) Validate() error {
+	return errors.New("order hook")
+}
+
+func TestPartial(t *testing.T) {
+	order := partialOrder{
+		Name:    "order",
+		Address: &partialAddress{City: "Beijing"},
+		Items:   []partialItem{{Code: "a", Qty: 0}, {Code: "", Qty: 1}},
+		Labels:  map[string]string{"env": "", "app": "shop"},
+	}
+
+	valid := [][]string{
+		{"Name"},
+		{"Name", "Address.City"},
+		{"Items[0].Code", "Items[1].Qty"},
+		{`Labels["app"]`},
+		{"Unknown"},
+		{},
+	}
+
+	for _, paths := range valid {
+		if err := Partial(order, paths...); err != nil {
+			t.Errorf("partial validation of %v returns %v", paths, err)
+		}
+	}
+
+	invalid := []struct {
+		paths    []string
+		expected string
+	}{
+		{[]string{"Email"}, "Email"},
+		{[]string{"Address.Country"}, "Address.Country"},
+		{[]string{"Name", "Address"}, "Address.Country"},
+		{[]string{"Items.Qty"}, "Items[0].Qty"},
+		{[]string{"Items[0].Qty", "Items.Code"}, "Items[0].Qty"},
+		{[]string{"Items[1]"}, "Items[1].Code"},
+		{[]string{"Labels"}, `Labels["env"]`},
+	}
+
+	for _, c := range invalid {
+		err := Partial(order, c.paths...)
+		if err == nil {
+			t.Errorf("partial validation of %v does not validate", c.paths)
+			continue
+		}
+
+		if path := err.(ErrorField).FieldPath(); path != c.expected {
+			t.Errorf("partial validation of %v reports %q, expected %q", c.paths, path, c.expected)
+		}
+	}
+
+	option, err := New(CollectAll())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs, ok := option.Partial(order, "Items[0].Qty", "Items.Code").(Errors); !ok || len(errs) != 2 {
+		t.Errorf("partial validation of Items[0].Qty and Items.Code returns %v", errs)
+	}
+
+	if err := Partial(partialOrder{}, ""); err == nil || err.Error() != "order hook" {
+		t.Errorf("partial validation of the whole element returns %v", err)
+	}
+}
+
+func TestPartialHooks(t *testing.T) {
+	option, err := New(PartialHooks())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := option.Partial(partialOrder{Name: "order"}, "Name"); err == nil || err.Error() != "order hook" {
+		t.Errorf("partial validation does not call hooks, returns %v", err)
+	}
+}
+
+func TestPartialMap(t *testing.T) {
+	order := partialOrder{
+		Name:    "order",
+		Address: &partialAddress{City: "Beijing"},
+		Items:   []partialItem{{Code: "a", Qty: 0}},
+	}
+
+	present := map[string]interface{}{
+		"name":    "order",
+		"address": map[string]interface{}{"city": "Beijing"},
+	}
+
+	if err := PartialMap(order, present, FieldNameTag("json")); err != nil {
+		t.Errorf("partial validation of %v returns %v", present, err)
+	}
+
+	present["address"] = map[string]interface{}{"country": "CHN"}
+
+	if err := PartialMap(order, present, FieldNameTag("json")); err == nil {
+		t.Errorf("partial validation of %v does not validate", present)
+	} else if path := err.(ErrorField).FieldPath(); path != "address.country" {
+		t.Errorf("partial validation of %v reports %q", present, path)
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	segments := splitPath(`Items[1].Labels["a.b]"].Name`)
+	expected := []string{"Items", "[1]", "Labels", `["a.b]"]`, "Name"}
+
+	if !reflect.DeepEqual(segments, expected) {
+		t.Errorf("splitPath returns %q, expected %q", segments, expected)
+	}
+}
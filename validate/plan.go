@@ -0,0 +1,367 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// expression is a compiled validators expression, see splitValidators and parseValidators.
+type expression struct {
+	// err is a syntax error found when compiling the expression.
+	err ErrorField
+	// validators is the text of the value validators, it is used to report syntax errors.
+	validators string
+	// validatorsOr is the parsed value validators.
+	validatorsOr [][]validator
+	// keys is the text of map key validators, key is compiled one.
+	keys string
+	key  *expression
+	// elems is the text of the validators of the next level, elem is compiled one.
+	elems string
+	elem  *expression
+	// guard is the condition of an if expression, then is the expression checked if the condition is true.
+	guard *guard
+	then  *expression
+}
+
+// fieldPlan is a compiled struct field.
+type fieldPlan struct {
+	index int
+	name  string
+	expr  *expression
+	// message is a custom message template of the field.
+	message string
+	// mod is the modifiers expression of the field.
+	mod *expression
+}
+
+// structPlan is a compiled struct.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+// planKey is a key of compiled structs, plans depend on options which affect how tags are read.
+type planKey struct {
+	typ            reflect.Type
+	tagName        string
+	messageTagName string
+	fieldNameTag   string
+	groupsTagName  string
+	groups         string
+	modTagName     string
+}
+
+// nolint:gochecknoglobals
+var (
+	expressionCache sync.Map // map[string]*expression
+	planCache       sync.Map // map[planKey]*structPlan
+
+	// emptyExpression has no validators on any level.
+	emptyExpression = func() *expression {
+		expr := &expression{}
+		expr.key, expr.elem = expr, expr
+
+		return expr
+	}()
+)
+
+// compileExpression compiles validators once and caches the result.
+func compileExpression(validators string) *expression {
+	if validators == "" {
+		return emptyExpression
+	}
+
+	if expr, ok := expressionCache.Load(validators); ok {
+		return expr.(*expression)
+	}
+
+	expr := &expression{}
+
+	if g, rest, ok, err := cutGuard(validators); ok {
+		expr.validators, expr.err = validators, err
+		expr.key, expr.elem = emptyExpression, emptyExpression
+
+		if err == nil {
+			expr.guard, expr.then = g, compileExpression(rest)
+		}
+
+		actual, _ := expressionCache.LoadOrStore(validators, expr)
+
+		return actual.(*expression)
+	}
+
+	keyValidators, valueValidators, remaningValidators, err := splitValidators(validators)
+	if err != nil {
+		expr.err = err
+	} else if expr.validatorsOr, err = parseValidators(valueValidators); err != nil {
+		expr.err = err
+	}
+
+	expr.validators = valueValidators
+
+	if expr.err == nil {
+		expr.keys, expr.elems = keyValidators, remaningValidators
+		expr.key = compileExpression(keyValidators)
+		expr.elem = compileExpression(remaningValidators)
+	}
+
+	actual, _ := expressionCache.LoadOrStore(validators, expr)
+
+	return actual.(*expression)
+}
+
+// getStructPlan compiles fields of a struct type once per option tag names and caches the result.
+func (o *Option) getStructPlan(typ reflect.Type) *structPlan {
+	key := planKey{
+		typ:            typ,
+		tagName:        o.TagName,
+		messageTagName: o.MessageTagName,
+		fieldNameTag:   o.FieldNameTag,
+		groupsTagName:  o.GroupsTagName,
+		groups:         strings.Join(o.Groups, ","),
+		modTagName:     o.ModTagName,
+	}
+	if plan, ok := planCache.Load(key); ok {
+		return plan.(*structPlan)
+	}
+
+	plan := &structPlan{fields: make([]fieldPlan, typ.NumField())}
+
+	for i := range plan.fields {
+		field := typ.Field(i)
+		plan.fields[i] = fieldPlan{
+			index:   i,
+			name:    o.fieldName(field),
+			expr:    compileExpression(o.selectValidators(o.getValidators(field.Tag), field.Tag.Get(o.GroupsTagName))),
+			message: field.Tag.Get(o.MessageTagName),
+			mod:     compileExpression(field.Tag.Get(o.ModTagName)),
+		}
+	}
+
+	actual, _ := planCache.LoadOrStore(key, plan)
+
+	return actual.(*structPlan)
+}
+
+// fieldName returns the name of a field reported in errors.
+// It is taken from the FieldNameTag tag when the option is set, fields of embedded structs are reported
+// without the name of the embedded struct like encoding/json does.
+func (o *Option) fieldName(field reflect.StructField) string {
+	// a blank field stands for the struct itself, see struct-level validators like one_of_required
+	if field.Name == "_" {
+		return ""
+	}
+
+	if o.FieldNameTag == "" {
+		return field.Name
+	}
+
+	name := field.Tag.Get(o.FieldNameTag)
+	if i := strings.Index(name, ","); i >= 0 {
+		name = name[:i]
+	}
+
+	switch {
+	case name == "-":
+		return field.Name
+	case name != "":
+		return name
+	case field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct:
+		return ""
+	default:
+		return field.Name
+	}
+}
+
+// Compile compiles validators of a type and reports syntax errors.
+// It accepts a value or a reflect.Type, e.g. validate.Compile(Request{}).
+// Compiled validators are cached, so it is useful to call Compile at startup
+// to find syntax errors early and to speed up the first validation.
+// Custom validators and formats should be registered before Compile.
+func Compile(element interface{}, optionFns ...OptionFn) error {
+	option, err := createOption(optionFns)
+	if err != nil {
+		return err
+	}
+
+	return option.Compile(element)
+}
+
+// Compile compiles validators of a type with the option, see Compile function.
+func (o *Option) Compile(element interface{}) error {
+	typ, ok := element.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(element)
+	}
+
+	if typ == nil {
+		return nil
+	}
+
+	return o.compileType(typ, "", compileExpression(""), nil, map[reflect.Type]bool{})
+}
+
+// compileType checks an expression against a type and compiles nested types.
+// parents contains the struct type of the field and all struct types above it, the nearest last.
+// nolint:gocognit
+func (o *Option) compileType(typ reflect.Type, fieldName string, expr *expression,
+	parents []reflect.Type, visited map[reflect.Type]bool) error {
+	if expr.err != nil {
+		return setFieldName(expr.err, fieldName)
+	}
+
+	if expr.guard != nil {
+		for _, field := range expr.guard.fields {
+			if !resolveFieldType(parents, field) {
+				return ErrorSyntax{
+					fieldName:  fieldName,
+					expression: expr.guard.text,
+					near:       field,
+					comment:    "could not find a field",
+				}
+			}
+		}
+
+		return o.compileType(typ, fieldName, expr.then, parents, visited)
+	}
+
+	if err := o.checkValidators(typ, fieldName, expr, parents); err != nil {
+		return err
+	}
+
+	kind := typ.Kind()
+
+	if kind != reflect.Map && expr.keys != "" {
+		return ErrorSyntax{fieldName: fieldName, expression: expr.keys, comment: "unexpexted expression"}
+	}
+
+	switch kind {
+	case reflect.Struct:
+		if visited[typ] {
+			return nil
+		}
+
+		visited[typ] = true
+		parents = append(parents[:len(parents):len(parents)], typ)
+
+		for _, f := range o.getStructPlan(typ).fields {
+			if err := o.compileModifiers(typ.Field(f.index).Type, f.name, f.mod, parents); err != nil {
+				return withPath(err, f.name)
+			}
+
+			if err := o.compileType(typ.Field(f.index).Type, f.name, f.expr, parents, visited); err != nil {
+				return withPath(err, f.name)
+			}
+		}
+	case reflect.Map:
+		if err := o.compileType(typ.Key(), fieldName, expr.key, parents, visited); err != nil {
+			return err
+		}
+
+		return o.compileType(typ.Elem(), fieldName, expr.elem, parents, visited)
+	case reflect.Slice, reflect.Array, reflect.Ptr:
+		return o.compileType(typ.Elem(), fieldName, expr.elem, parents, visited)
+	}
+
+	if expr.elems != "" {
+		return ErrorSyntax{fieldName: fieldName, expression: expr.elems, comment: "unexpexted expression"}
+	}
+
+	return nil
+}
+
+// checkValidators checks that validators exist and accept their values for the type.
+// Built-in validators are run against the zero value of the type to find syntax errors,
+// fields referenced by cross-field validators are looked up in the parent types.
+func (o *Option) checkValidators(typ reflect.Type, fieldName string, expr *expression, parents []reflect.Type) error {
+	zero := reflect.Zero(typ)
+
+	for _, validatorsAnd := range expr.validatorsOr {
+		for _, v := range validatorsAnd {
+			errorSyntax := ErrorSyntax{
+				fieldName:  fieldName,
+				expression: string(v.Type),
+				near:       expr.validators,
+				comment:    "could not find a validator",
+			}
+
+			if _, ok := getCrossFieldValidatorTypeMap()[v.Type]; ok {
+				fields, _, isGroup, err := parseGroupValidator(v.Type, v.Value)
+				if !isGroup {
+					fields = []string{strings.SplitN(v.Value, " ", 2)[0]}
+				}
+
+				errorSyntax.expression, errorSyntax.near = v.Value, string(v.Type)
+
+				if err != nil {
+					errorSyntax.comment = "could not parse"
+					return errorSyntax
+				}
+
+				for _, field := range fields {
+					if !resolveFieldType(parents, field) {
+						errorSyntax.comment = "could not find a field"
+						return errorSyntax
+					}
+				}
+
+				continue
+			}
+
+			validatorFunc, ok := o.lookupValidator(v.Type)
+			if !ok {
+				return errorSyntax
+			}
+
+			if o.isCustomValidator(v.Type) {
+				continue
+			}
+
+			if err, ok := validatorFunc(zero, v.Value).(ErrorSyntax); ok {
+				return setFieldName(err, fieldName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveFieldType checks that a field referenced by path exists, see resolveField.
+// Fields behind interfaces could not be checked and are considered to exist.
+func resolveFieldType(parents []reflect.Type, path string) bool {
+	level := 0
+	for strings.HasPrefix(path, "..") {
+		path = path[2:]
+		level++
+	}
+
+	if level >= len(parents) || path == "" {
+		return false
+	}
+
+	typ := parents[len(parents)-1-level]
+
+	for _, name := range strings.Split(path, ".") {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() == reflect.Interface {
+			return true
+		}
+
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return false
+		}
+
+		typ = field.Type
+	}
+
+	return true
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	type Node struct {
+		Name     string  `validate:"empty=false"`
+		Children []*Node `validate:"> nil=false"`
+	}
+
+	if nil != Compile(Node{}) {
+		t.Errorf("compile does not compile recursive type")
+	}
+
+	if nil != Compile(reflect.TypeOf(map[string][]Node{})) {
+		t.Errorf("compile does not compile reflect.Type")
+	}
+
+	tests := []struct {
+		element interface{}
+		path    string
+	}{
+		{struct {
+			field int `validate:"gte=0 [eq=0s"`
+		}{}, "field"},
+		{struct {
+			field int `validate:"unknown=1"`
+		}{}, "field"},
+		{struct {
+			field int `validate:"gte=abc"`
+		}{}, "field"},
+		{struct {
+			field string `validate:"format=unknown"`
+		}{}, "field"},
+		{struct {
+			field int `validate:"> gte=0"`
+		}{}, "field"},
+		{struct {
+			field []int `validate:"[gte=0]"`
+		}{}, "field"},
+		{struct {
+			field map[string]int `validate:"> nil=false"`
+		}{}, "field"},
+		{struct {
+			field int `validate:"eqfield=missing"`
+		}{}, "field"},
+		{struct {
+			items []struct {
+				field int `validate:"eqfield=..missing"`
+			}
+		}{}, "items.field"},
+		{&struct {
+			items map[string]*struct {
+				field int `validate:"lte=-"`
+			}
+		}{}, "items.field"},
+	}
+
+	for _, test := range tests {
+		err := Compile(test.element)
+		if e, ok := err.(ErrorSyntax); !ok || e.FieldPath() != test.path {
+			t.Errorf("compile does not report syntax error of %T: %v", test.element, err)
+		}
+	}
+}
+
+func TestCompileConcurrent(t *testing.T) {
+	type St struct {
+		field []int `validate:"empty=false > gte=0"`
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if nil == Validate(St{field: []int{-1}}) {
+				t.Errorf("validate does not validate concurrently")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+type benchmarkItem struct {
+	ID   string            `validate:"format=uuid"`
+	Qty  int               `validate:"gte=1 & lte=100"`
+	Tags map[string]string `validate:"lte=10 [empty=false] > lte=64"`
+}
+
+type benchmarkRequest struct {
+	Email string           `validate:"format=email"`
+	Role  string           `validate:"enum=admin,publisher,author"`
+	Items []benchmarkItem  `validate:"empty=false & lte=100"`
+	Meta  *benchmarkItem   `validate:"nil=true | nil=false"`
+	Extra []*benchmarkItem `validate:"> nil=false"`
+}
+
+func newBenchmarkRequest() benchmarkRequest {
+	item := benchmarkItem{
+		ID:   "a987fbc9-4bed-3078-cf07-9141ba07c9f3",
+		Qty:  2,
+		Tags: map[string]string{"env": "prod", "zone": "a"},
+	}
+
+	return benchmarkRequest{
+		Email: "admin@example.com",
+		Role:  "admin",
+		Items: []benchmarkItem{item, item, item},
+		Meta:  &item,
+		Extra: []*benchmarkItem{&item},
+	}
+}
+
+// resetCaches drops compiled expressions and plans.
+func resetCaches() {
+	expressionCache = sync.Map{}
+	planCache = sync.Map{}
+}
+
+func BenchmarkValidateCold(b *testing.B) {
+	request := newBenchmarkRequest()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		resetCaches()
+
+		if err := Validate(request); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateWarm(b *testing.B) {
+	request := newBenchmarkRequest()
+
+	if err := Compile(request); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := Validate(request); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Following validators check postcodes by patterns of countries, which are ISO 3166-1 alpha-2 codes.
+// Format postcode checks postcodes of the United Kingdom only.
+const (
+	// Postcode checks if a string is a postcode of the given country.
+	// E.g. `validate:"postcode=CN"`
+	Postcode Type = "postcode"
+
+	// PostcodeField checks if a string is a postcode of the country in another field.
+	// E.g. `validate:"postcode_field=Country"`
+	PostcodeField = "postcode_field"
+)
+
+// matchPostcode checks if the value is a postcode of the country, it returns false if the country is unknown.
+func matchPostcode(country, value string) (matched, known bool) {
+	regex, ok := postcodeRegexes[strings.ToUpper(country)]
+	if !ok {
+		return false, false
+	}
+
+	return regex.MatchString(value), true
+}
+
+func validatePostcode(value reflect.Value, validator string) ErrorField {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(Postcode),
+		comment:    "could not find country",
+	}
+
+	if value.Kind() != reflect.String {
+		errorSyntax.comment = "could not parse or run"
+		return errorSyntax
+	}
+
+	matched, known := matchPostcode(validator, value.String())
+	if !known {
+		return errorSyntax
+	}
+
+	if !matched {
+		return ErrorValidation{
+			fieldValue:     value,
+			validatorType:  Postcode,
+			validatorValue: validator,
+		}
+	}
+
+	return nil
+}
+
+// validatePostcodeField validates a postcode of the country in another field,
+// the postcode is invalid if the country is unknown.
+func validatePostcodeField(value reflect.Value, validator string, parents []reflect.Value) ErrorField {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(PostcodeField),
+		comment:    "could not parse or run",
+	}
+
+	other, err := resolveField(parents, validator)
+	if err != nil || value.Kind() != reflect.String {
+		return errorSyntax
+	}
+
+	if other = indirectValue(other); other.Kind() == reflect.String {
+		if matched, _ := matchPostcode(other.String(), value.String()); matched {
+			return nil
+		}
+	}
+
+	return ErrorValidation{
+		fieldValue:     value,
+		validatorType:  PostcodeField,
+		validatorValue: validator,
+	}
+}
@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPostcodeVal(t *testing.T) {
+	tests := []struct {
+		country  string
+		postcode string
+		valid    bool
+	}{
+		{"CN", "100080", true},
+		{"cn", "100080", true},
+		{"CN", "10008", false},
+		{"US", "94105", true},
+		{"US", "94105-1804", true},
+		{"US", "9410", false},
+		{"GB", "SW1A 1AA", true},
+		{"GB", "12345", false},
+		{"CA", "K1A 0B1", true},
+		{"CA", "D1A 0B1", false},
+		{"JP", "100-0001", true},
+		{"NL", "1012 AB", true},
+		{"DE", "10115", true},
+		{"DE", "1011", false},
+	}
+
+	for _, test := range tests {
+		err := validatePostcode(reflect.ValueOf(test.postcode), test.country)
+		if (err == nil) != test.valid {
+			t.Errorf("postcode validator does not validate %q of %s, expected %v", test.postcode, test.country, test.valid)
+		}
+	}
+
+	if nil != Validate(struct {
+		Zip string `validate:"postcode=CN"`
+	}{"100080"}) {
+		t.Errorf("postcode validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		Zip string `validate:"postcode=CN"`
+	}{"SW1A 1AA"}) {
+		t.Errorf("postcode validator does not validate")
+	}
+
+	if _, ok := Validate(struct {
+		Zip string `validate:"postcode=XX"`
+	}{"100080"}).(ErrorSyntax); !ok {
+		t.Errorf("postcode validator does not report an unknown country")
+	}
+}
+
+type shippingAddress struct {
+	Country string
+	Zip     string `validate:"postcode_field=Country"`
+}
+
+func TestPostcodeFieldVal(t *testing.T) {
+	if nil != Validate(shippingAddress{Country: "CN", Zip: "100080"}) {
+		t.Errorf("postcode_field validator does not validate")
+	}
+
+	if nil != Validate(shippingAddress{Country: "us", Zip: "94105"}) {
+		t.Errorf("postcode_field validator does not validate")
+	}
+
+	if nil == Validate(shippingAddress{Country: "US", Zip: "100080"}) {
+		t.Errorf("postcode_field validator does not validate")
+	}
+
+	if nil == Validate(shippingAddress{Country: "XX", Zip: "100080"}) {
+		t.Errorf("postcode_field validator does not validate")
+	}
+
+	option, _ := New(Locale(LocaleEn))
+	if err := option.Validate(shippingAddress{Country: "US", Zip: "100080"}); err == nil {
+		t.Errorf("postcode_field validator does not validate")
+	} else if err.Error() != "Zip must be a valid postcode of the country" {
+		t.Errorf("postcode_field validator reports %q", err.Error())
+	}
+
+	if err := Compile(struct {
+		Zip string `validate:"postcode_field=Country"`
+	}{}); err == nil {
+		t.Errorf("postcode_field validator does not report a missing field")
+	}
+}
@@ -40,7 +40,13 @@ const (
 	ethAddressUpperRegexString       = `^0x[0-9A-F]{40}$`
 	ethAddressLowerRegexString       = `^0x[0-9a-f]{40}$`
 	uRLEncodedRegexString            = `(%[A-Fa-f0-9]{2})`
-	postcodeRegexString              = `^([Gg][Ii][Rr] 0[Aa]{2})|((([A-Za-z][0-9]{1,2})|(([A-Za-z][A-Ha-hJ-Yj-y][0-9]{1,2})|(([A-Za-z][0-9][A-Za-z])|([A-Za-z][A-Ha-hJ-Yj-y][0-9]?[A-Za-z])))) [0-9][A-Za-z]{2})$` // https://stackoverflow.com/questions/164979/regex-for-matching-uk-postcodes/51885364#51885364
+	postcodeRegexString              = `^(?:([Gg][Ii][Rr] 0[Aa]{2})|((([A-Za-z][0-9]{1,2})|(([A-Za-z][A-Ha-hJ-Yj-y][0-9]{1,2})|(([A-Za-z][0-9][A-Za-z])|([A-Za-z][A-Ha-hJ-Yj-y][0-9]?[A-Za-z])))) [0-9][A-Za-z]{2}))$` // https://stackoverflow.com/questions/164979/regex-for-matching-uk-postcodes/51885364#51885364
+	e164RegexString                  = `^\+[1-9][0-9]{1,14}$`                                                                                                                                                          // https://en.wikipedia.org/wiki/E.164
+	cnMobileRegexString              = `^(?:(?:\+|00)86)?1[3-9][0-9]{9}$`
+	cnIDRegexString                  = `^[1-9][0-9]{16}[0-9Xx]$`
+	iBANRegexString                  = `^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`
+	semverRegexString                = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$` // https://semver.org
+	jWTRegexString                   = `^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`
 )
 
 // nolint:gochecknoglobals
@@ -82,4 +88,71 @@ var (
 	ethAddressRegexLower       = regexp.MustCompile(ethAddressLowerRegexString)
 	uRLEncodedRegex            = regexp.MustCompile(uRLEncodedRegexString)
 	postcodeRegex              = regexp.MustCompile(postcodeRegexString)
+	e164Regex                  = regexp.MustCompile(e164RegexString)
+	cnMobileRegex              = regexp.MustCompile(cnMobileRegexString)
+	cnIDRegex                  = regexp.MustCompile(cnIDRegexString)
+	iBANRegex                  = regexp.MustCompile(iBANRegexString)
+	semverRegex                = regexp.MustCompile(semverRegexString)
+	jWTRegex                   = regexp.MustCompile(jWTRegexString)
 )
+
+// postcodeRegexStrings contains patterns of postcodes by ISO 3166-1 alpha-2 country codes.
+// nolint:gochecknoglobals,lll
+var postcodeRegexStrings = map[string]string{
+	"AR": `^(?:[A-HJ-NP-Z][0-9]{4}[A-Z]{3}|[0-9]{4})$`,
+	"AT": `^[1-9][0-9]{3}$`,
+	"AU": `^[0-9]{4}$`,
+	"BE": `^[1-9][0-9]{3}$`,
+	"BR": `^[0-9]{5}-?[0-9]{3}$`,
+	"CA": `^[ABCEGHJ-NPRSTVXY][0-9][ABCEGHJ-NPRSTV-Z] ?[0-9][ABCEGHJ-NPRSTV-Z][0-9]$`,
+	"CH": `^[1-9][0-9]{3}$`,
+	"CN": `^[0-9]{6}$`,
+	"CZ": `^[0-9]{3} ?[0-9]{2}$`,
+	"DE": `^[0-9]{5}$`,
+	"DK": `^[1-9][0-9]{3}$`,
+	"ES": `^(?:0[1-9]|[1-4][0-9]|5[0-2])[0-9]{3}$`,
+	"FI": `^[0-9]{5}$`,
+	"FR": `^[0-9]{5}$`,
+	"GB": postcodeRegexString,
+	"GR": `^[0-9]{3} ?[0-9]{2}$`,
+	"HU": `^[1-9][0-9]{3}$`,
+	"ID": `^[0-9]{5}$`,
+	"IE": `^(?:[AC-FHKNPRTV-Y][0-9]{2}|D6W) ?[0-9AC-FHKNPRTV-Y]{4}$`,
+	"IL": `^[0-9]{5}(?:[0-9]{2})?$`,
+	"IN": `^[1-9][0-9]{5}$`,
+	"IT": `^[0-9]{5}$`,
+	"JP": `^[0-9]{3}-?[0-9]{4}$`,
+	"KR": `^[0-9]{5}$`,
+	"MX": `^[0-9]{5}$`,
+	"MY": `^[0-9]{5}$`,
+	"NL": `^[1-9][0-9]{3} ?[A-Za-z]{2}$`,
+	"NO": `^[0-9]{4}$`,
+	"NZ": `^[0-9]{4}$`,
+	"PH": `^[0-9]{4}$`,
+	"PL": `^[0-9]{2}-[0-9]{3}$`,
+	"PT": `^[1-9][0-9]{3}-[0-9]{3}$`,
+	"RU": `^[0-9]{6}$`,
+	"SE": `^[1-9][0-9]{2} ?[0-9]{2}$`,
+	"SG": `^[0-9]{6}$`,
+	"SK": `^[0-9]{3} ?[0-9]{2}$`,
+	"TH": `^[0-9]{5}$`,
+	"TR": `^[0-9]{5}$`,
+	"TW": `^[0-9]{3}(?:[0-9]{2,3})?$`,
+	"US": `^[0-9]{5}(?:-[0-9]{4})?$`,
+	"VN": `^[0-9]{6}$`,
+	"ZA": `^[0-9]{4}$`,
+}
+
+// nolint:gochecknoglobals
+var postcodeRegexes = compileRegexes(postcodeRegexStrings)
+
+// compileRegexes compiles patterns keyed by names.
+func compileRegexes(patterns map[string]string) map[string]*regexp.Regexp {
+	regexes := make(map[string]*regexp.Regexp, len(patterns))
+
+	for name, pattern := range patterns {
+		regexes[name] = regexp.MustCompile(pattern)
+	}
+
+	return regexes
+}
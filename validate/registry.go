@@ -0,0 +1,226 @@
+package validate
+
+import (
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// ValidatorFunc is a custom validator func.
+// It gets the value to validate and the text after the equal sign, e.g. "true" for `validate:"luhn=true"`.
+type ValidatorFunc func(value reflect.Value, validator string) error
+
+// registry keeps custom validators, formats, message catalogs, regular expressions and modifiers,
+// it is safe for concurrent use.
+type registry struct {
+	mu         sync.RWMutex
+	validators map[Type]ValidatorFunc
+	formats    map[FormatType]FormatFunc
+	messages   map[string]Messages
+	regexes    map[string]*regexp.Regexp
+	modifiers  map[ModifierType]ModifierFunc
+}
+
+// nolint:gochecknoglobals
+var globalRegistry = &registry{}
+
+// RegisterValidator registers a custom validator globally.
+// The validator could be used in tags like a built-in one, e.g. `validate:"luhn=true"`.
+func RegisterValidator(name string, fn ValidatorFunc) {
+	globalRegistry.registerValidator(Type(name), fn)
+}
+
+// RegisterFormat registers a custom format globally.
+// The format could be used in tags like a built-in one, e.g. `validate:"format=sku"`.
+func RegisterFormat(name string, fn FormatFunc) {
+	globalRegistry.registerFormat(FormatType(name), fn)
+}
+
+// RegisterRegex registers a regular expression globally.
+// The regular expression could be used by its name in regex validator, e.g. `validate:"regex=sku"`.
+func RegisterRegex(name string, re *regexp.Regexp) {
+	globalRegistry.registerRegex(name, re)
+}
+
+// RegisterValidator registers a custom validator only for the option.
+func (o *Option) RegisterValidator(name string, fn ValidatorFunc) {
+	o.registry.registerValidator(Type(name), fn)
+}
+
+// RegisterFormat registers a custom format only for the option.
+func (o *Option) RegisterFormat(name string, fn FormatFunc) {
+	o.registry.registerFormat(FormatType(name), fn)
+}
+
+// RegisterRegex registers a regular expression only for the option.
+func (o *Option) RegisterRegex(name string, re *regexp.Regexp) {
+	o.registry.registerRegex(name, re)
+}
+
+func (r *registry) registerValidator(name Type, fn ValidatorFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.validators == nil {
+		r.validators = make(map[Type]ValidatorFunc)
+	}
+
+	r.validators[name] = fn
+}
+
+func (r *registry) registerFormat(name FormatType, fn FormatFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.formats == nil {
+		r.formats = make(map[FormatType]FormatFunc)
+	}
+
+	r.formats[name] = fn
+}
+
+func (r *registry) registerRegex(name string, re *regexp.Regexp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.regexes == nil {
+		r.regexes = make(map[string]*regexp.Regexp)
+	}
+
+	r.regexes[name] = re
+}
+
+func (r *registry) registerModifier(name ModifierType, fn ModifierFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.modifiers == nil {
+		r.modifiers = make(map[ModifierType]ModifierFunc)
+	}
+
+	r.modifiers[name] = fn
+}
+
+func (r *registry) validator(name Type) (ValidatorFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.validators[name]
+
+	return fn, ok
+}
+
+func (r *registry) format(name FormatType) (FormatFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.formats[name]
+
+	return fn, ok
+}
+
+func (r *registry) regex(name string) (*regexp.Regexp, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	re, ok := r.regexes[name]
+
+	return re, ok
+}
+
+func (r *registry) modifier(name ModifierType) (ModifierFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.modifiers[name]
+
+	return fn, ok
+}
+
+// lookupValidator finds a validator registered for the option, then a global one, then a built-in one.
+func (o *Option) lookupValidator(name Type) (validatorFunc, bool) {
+	switch name {
+	case Format:
+		return o.validateFormat, true
+	case Regex:
+		return o.validateRegex, true
+	}
+
+	if fn, ok := o.registry.validator(name); ok {
+		return wrapValidatorFunc(name, fn), true
+	}
+
+	if fn, ok := globalRegistry.validator(name); ok {
+		return wrapValidatorFunc(name, fn), true
+	}
+
+	fn, ok := getValidatorTypeMap()[name]
+
+	return fn, ok
+}
+
+// isCustomValidator checks if a validator is registered for the option or globally.
+func (o *Option) isCustomValidator(name Type) bool {
+	if _, ok := o.registry.validator(name); ok {
+		return true
+	}
+
+	_, ok := globalRegistry.validator(name)
+
+	return ok
+}
+
+// lookupFormat finds a format registered for the option, then a global one, then a built-in one.
+func (o *Option) lookupFormat(name FormatType) (FormatFunc, bool) {
+	if fn, ok := o.registry.format(name); ok {
+		return fn, true
+	}
+
+	if fn, ok := globalRegistry.format(name); ok {
+		return fn, true
+	}
+
+	fn, ok := getFormatTypeMap()[name]
+
+	return fn, ok
+}
+
+// validateFormat validates a format with the custom formats of the option.
+func (o *Option) validateFormat(value reflect.Value, validator string) ErrorField {
+	return validateFormatWith(value, validator, o.lookupFormat)
+}
+
+// lookupRegex finds a regular expression registered for the option, then a global one.
+func (o *Option) lookupRegex(name string) (*regexp.Regexp, bool) {
+	if re, ok := o.registry.regex(name); ok {
+		return re, true
+	}
+
+	return globalRegistry.regex(name)
+}
+
+// validateRegex validates a string with the regular expressions of the option.
+func (o *Option) validateRegex(value reflect.Value, validator string) ErrorField {
+	return validateRegexWith(value, validator, o.lookupRegex)
+}
+
+// wrapValidatorFunc adapts a custom validator to the internal validator func.
+func wrapValidatorFunc(name Type, fn ValidatorFunc) validatorFunc {
+	return func(value reflect.Value, validator string) ErrorField {
+		err := fn(value, validator)
+		if err == nil {
+			return nil
+		}
+
+		if errField, ok := err.(ErrorField); ok {
+			return errField
+		}
+
+		return ErrorValidation{
+			fieldValue:     value,
+			validatorType:  name,
+			validatorValue: validator,
+			err:            err,
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func luhn(value reflect.Value, validator string) error {
+	enabled, err := strconv.ParseBool(validator)
+	if err != nil || !enabled {
+		return err
+	}
+
+	sum := 0
+	digits := value.String()
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+	}
+
+	if sum%10 != 0 {
+		return errors.New("bad checksum")
+	}
+
+	return nil
+}
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("luhn", luhn)
+
+	type Card struct {
+		Number string `validate:"empty=false & luhn=true"`
+	}
+
+	if nil != Validate(Card{Number: "4539578763621486"}) {
+		t.Errorf("custom validator does not validate")
+	}
+
+	err := Validate(Card{Number: "4539578763621487"})
+
+	var errValidation ErrorValidation
+	if !errors.As(err, &errValidation) || errValidation.FieldName() != "Number" {
+		t.Fatalf("custom validator does not return ErrorValidation")
+	}
+
+	if !strings.HasSuffix(err.Error(), "bad checksum") {
+		t.Errorf("custom validator error is lost: %v", err)
+	}
+
+	if nil == Validate(struct {
+		Numbers []string `validate:"> luhn=true"`
+	}{
+		Numbers: []string{"4539578763621486", "1"},
+	}) {
+		t.Errorf("custom validator does not validate slice elements")
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("sku", func(value string) bool { return strings.HasPrefix(value, "SKU-") })
+
+	type Item struct {
+		Code string `validate:"format=sku | format=uuid"`
+	}
+
+	if nil != Validate(Item{Code: "SKU-1"}) {
+		t.Errorf("custom format does not validate")
+	}
+
+	if nil == Validate(Item{Code: "ABC-1"}) {
+		t.Errorf("custom format does not validate")
+	}
+}
+
+func TestOptionRegistry(t *testing.T) {
+	type Item struct {
+		Code string `validate:"format=upper"`
+	}
+
+	if _, ok := Validate(Item{Code: "A"}).(ErrorSyntax); !ok {
+		t.Errorf("format registered for an option leaks into global")
+	}
+
+	option, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	option.RegisterFormat("upper", func(value string) bool { return strings.ToUpper(value) == value })
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if nil != option.Validate(Item{Code: "A"}) {
+				t.Errorf("custom format of option does not validate")
+			}
+
+			if nil == option.Validate(Item{Code: "a"}) {
+				t.Errorf("custom format of option does not validate")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
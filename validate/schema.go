@@ -0,0 +1,430 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaDraft is the JSON Schema dialect of generated schemas.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// nolint:gochecknoglobals
+var (
+	// schemaFormats maps formats to JSON Schema formats.
+	schemaFormats = map[FormatType]string{
+		FormatEmail:           "email",
+		FormatURL:             "uri",
+		FormatURI:             "uri",
+		FormatUrnRFC2141:      "uri",
+		FormatUUID:            "uuid",
+		FormatUUID3:           "uuid",
+		FormatUUID4:           "uuid",
+		FormatUUID5:           "uuid",
+		FormatIPv4:            "ipv4",
+		FormatIPv6:            "ipv6",
+		FormatHostnameRFC952:  "hostname",
+		FormatHostnameRFC1123: "hostname",
+		FormatFQDN:            "hostname",
+		FormatRFC3339:         "date-time",
+		FormatDate:            "date",
+	}
+
+	// schemaPatterns maps formats to regular expressions for formats unknown to JSON Schema.
+	schemaPatterns = map[FormatType]string{
+		FormatAlpha:           alphaRegexString,
+		FormatAlnum:           alnumRegexString,
+		FormatAlphaUnicode:    alphaUnicodeRegexString,
+		FormatAlnumUnicode:    alnumUnicodeRegexString,
+		FormatNumeric:         numericRegexString,
+		FormatNumber:          numberRegexString,
+		FormatHexadecimal:     hexadecimalRegexString,
+		FormatHEXColor:        hexcolorRegexString,
+		FormatRGB:             rgbRegexString,
+		FormatRGBA:            rgbaRegexString,
+		FormatHSL:             hslRegexString,
+		FormatHSLA:            hslaRegexString,
+		FormatBase64:          base64RegexString,
+		FormatBase64URL:       base64URLRegexString,
+		FormatEthereumAddress: ethAddressRegexString,
+		FormatBitcoinAddress:  btcAddressRegexString,
+		FormatPrintableASCII:  printableASCIIRegexString,
+		FormatLatitude:        latitudeRegexString,
+		FormatLongitude:       longitudeRegexString,
+		FormatSSN:             sSNRegexString,
+		FormatPostcode:        postcodeRegexString,
+		FormatE164:            e164RegexString,
+		FormatCNMobile:        cnMobileRegexString,
+		FormatSemver:          semverRegexString,
+	}
+)
+
+// JSONSchema generates a JSON Schema (draft 2020-12) document of a type from validate tags.
+// It accepts a value or a reflect.Type. Property names are taken from json tags.
+// Validators which could not be expressed in JSON Schema, e.g. custom and cross-field ones, are ignored.
+// Marshal the result with encoding/json to get the document.
+func JSONSchema(element interface{}, optionFns ...OptionFn) (map[string]interface{}, error) {
+	option, err := createOption(optionFns)
+	if err != nil {
+		return nil, err
+	}
+
+	return option.JSONSchema(element)
+}
+
+// JSONSchema generates a JSON Schema document with the option, see JSONSchema function.
+func (o *Option) JSONSchema(element interface{}) (map[string]interface{}, error) {
+	typ, ok := element.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(element)
+	}
+
+	if err := o.Compile(typ); err != nil {
+		return nil, err
+	}
+
+	g := &schemaGenerator{option: o, defs: map[string]interface{}{}, names: map[reflect.Type]string{}}
+	schema, _ := g.schema(typ, emptyExpression)
+	schema["$schema"] = SchemaDraft
+
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+
+	return schema, nil
+}
+
+// schemaGenerator generates schemas, named structs are generated once into $defs.
+type schemaGenerator struct {
+	option *Option
+	defs   map[string]interface{}
+	names  map[reflect.Type]string
+}
+
+// schema generates a schema of a type with the validators of expr.
+// It returns if the value is required to be set.
+func (g *schemaGenerator) schema(typ reflect.Type, expr *expression) (map[string]interface{}, bool) {
+	var schema map[string]interface{}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		schema, _ = g.schema(typ.Elem(), expr.elem)
+	case reflect.Struct:
+		schema = g.structSchema(typ)
+	case reflect.Map:
+		schema = map[string]interface{}{"type": "object"}
+
+		if keySchema, _ := g.schema(typ.Key(), expr.key); typ.Key().Kind() == reflect.String && len(keySchema) > 1 {
+			delete(keySchema, "type")
+			schema["propertyNames"] = keySchema
+		}
+
+		schema["additionalProperties"], _ = g.schema(typ.Elem(), expr.elem)
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			schema = map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+			break
+		}
+
+		schema = map[string]interface{}{"type": "array"}
+		schema["items"], _ = g.schema(typ.Elem(), expr.elem)
+
+		if typ.Kind() == reflect.Array {
+			schema["minItems"], schema["maxItems"] = typ.Len(), typ.Len()
+		}
+	default:
+		schema = map[string]interface{}{}
+		if t := schemaType(typ); t != "" {
+			schema["type"] = t
+		}
+	}
+
+	return g.applyValidators(schema, typ, expr)
+}
+
+// structSchema generates a schema of a struct, a named struct is referenced from $defs.
+func (g *schemaGenerator) structSchema(typ reflect.Type) map[string]interface{} {
+	if typ == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	if typ.Name() == "" {
+		return g.objectSchema(typ)
+	}
+
+	name, ok := g.names[typ]
+	if !ok {
+		name = typ.Name()
+		for i := 2; g.defs[name] != nil; i++ {
+			name = typ.Name() + strconv.Itoa(i)
+		}
+
+		g.names[typ] = name
+		g.defs[name] = map[string]interface{}{} // reserve the name for recursive types
+		g.defs[name] = g.objectSchema(typ)
+	}
+
+	return map[string]interface{}{"$ref": "#/$defs/" + name}
+}
+
+// objectSchema generates an object schema with properties of struct fields.
+func (g *schemaGenerator) objectSchema(typ reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := make([]string, 0)
+
+	g.addProperties(typ, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// addProperties adds properties of struct fields, embedded structs are flattened like encoding/json does.
+func (g *schemaGenerator) addProperties(typ reflect.Type, properties map[string]interface{}, required *[]string) {
+	for _, f := range g.option.getStructPlan(typ).fields {
+		field := typ.Field(f.index)
+		name, opts := parseJSONTag(field)
+
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			if t := indirectType(field.Type); t.Kind() == reflect.Struct {
+				g.addProperties(t, properties, required)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, isRequired := g.schema(field.Type, f.expr)
+		properties[name] = schema
+
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// applyValidators adds keywords of validators to a schema.
+// Alternatives of | operator are added as anyOf.
+func (g *schemaGenerator) applyValidators(schema map[string]interface{}, typ reflect.Type,
+	expr *expression) (map[string]interface{}, bool) {
+	switch len(expr.validatorsOr) {
+	case 0:
+		return schema, false
+	case 1:
+		return schema, addKeywords(schema, typ, expr.validatorsOr[0])
+	}
+
+	required := true
+	anyOf := make([]interface{}, 0, len(expr.validatorsOr))
+
+	for _, validatorsAnd := range expr.validatorsOr {
+		alternative := map[string]interface{}{}
+		required = addKeywords(alternative, typ, validatorsAnd) && required
+		anyOf = append(anyOf, alternative)
+	}
+
+	schema["anyOf"] = anyOf
+
+	return schema, required
+}
+
+// addKeywords adds keywords of validators combined with & operator to a schema.
+// It returns if the validators require the value to be set.
+// nolint:gocognit,gocyclo
+func addKeywords(schema map[string]interface{}, typ reflect.Type, validatorsAnd []validator) bool {
+	required := false
+	minKey, maxKey := lengthKeywords(typ)
+
+	for _, v := range validatorsAnd {
+		switch v.Type {
+		case Nil:
+			if isNil, err := strconv.ParseBool(v.Value); err == nil && !isNil {
+				required = true
+			}
+		case Empty:
+			isEmpty, err := strconv.ParseBool(v.Value)
+			if err != nil || minKey == "" {
+				continue
+			}
+
+			if isEmpty {
+				schema[maxKey] = 0
+			} else {
+				schema[minKey] = 1
+				required = true
+			}
+		case Eq, Ne, Gt, Gte, Lt, Lte:
+			addComparisonKeyword(schema, typ, v, minKey, maxKey)
+		case RunesEq, RunesGt, RunesLt, RunesGte, RunesLte:
+			// minLength and maxLength of JSON Schema count characters, not bytes
+			v.Type = map[Type]Type{RunesEq: Eq, RunesGt: Gt, RunesLt: Lt, RunesGte: Gte, RunesLte: Lte}[v.Type]
+			addComparisonKeyword(schema, typ, v, minKey, maxKey)
+		case Unique:
+			if v.Value == "" && minKey == "minItems" {
+				schema["uniqueItems"] = true
+			}
+		case Enum:
+			if values := schemaTokens(typ, v.Value); len(values) > 0 {
+				schema["enum"] = values
+			}
+		case Format:
+			if format, ok := schemaFormats[FormatType(v.Value)]; ok {
+				schema["format"] = format
+			} else if pattern, ok := schemaPatterns[FormatType(v.Value)]; ok {
+				schema["pattern"] = pattern
+			}
+		case Postcode:
+			if pattern, ok := postcodeRegexStrings[strings.ToUpper(v.Value)]; ok {
+				schema["pattern"] = pattern
+			}
+		}
+	}
+
+	return required
+}
+
+// addComparisonKeyword adds a keyword of a comparison validator,
+// it compares a number or a length of a string, an array or an object.
+func addComparisonKeyword(schema map[string]interface{}, typ reflect.Type, v validator, minKey, maxKey string) {
+	if minKey != "" {
+		n, err := strconv.Atoi(v.Value)
+		if err != nil {
+			return
+		}
+
+		switch v.Type {
+		case Eq:
+			schema[minKey], schema[maxKey] = n, n
+		case Ne:
+			schema["not"] = map[string]interface{}{minKey: n, maxKey: n}
+		case Gt:
+			schema[minKey] = n + 1
+		case Gte:
+			schema[minKey] = n
+		case Lt:
+			schema[maxKey] = n - 1
+		case Lte:
+			schema[maxKey] = n
+		}
+
+		return
+	}
+
+	values := schemaTokens(typ, v.Value)
+	if len(values) != 1 {
+		return
+	}
+
+	keyword := map[Type]string{
+		Eq: "const", Gt: "exclusiveMinimum", Gte: "minimum", Lt: "exclusiveMaximum", Lte: "maximum",
+	}[v.Type]
+
+	if v.Type == Ne {
+		schema["not"] = map[string]interface{}{"const": values[0]}
+	} else if keyword != "" {
+		schema[keyword] = values[0]
+	}
+}
+
+// lengthKeywords returns keywords for the minimal and the maximal length of a type.
+func lengthKeywords(typ reflect.Type) (string, string) {
+	switch typ.Kind() {
+	case reflect.String:
+		return "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		return "minItems", "maxItems"
+	case reflect.Map:
+		return "minProperties", "maxProperties"
+	}
+
+	return "", ""
+}
+
+// schemaTokens parses comma separated tokens into values of a type.
+func schemaTokens(typ reflect.Type, str string) []interface{} {
+	tokens := parseTokens(str)
+	values := make([]interface{}, 0, len(tokens))
+
+	for _, token := range tokens {
+		s := token.(string)
+
+		var (
+			value interface{}
+			err   error
+		)
+
+		switch kind := typ.Kind(); {
+		case typ == reflect.TypeOf(time.Duration(0)):
+			var d time.Duration
+			d, err = time.ParseDuration(s)
+			value = int64(d)
+		case kind >= reflect.Int && kind <= reflect.Int64:
+			value, err = strconv.ParseInt(s, 10, 64)
+		case kind >= reflect.Uint && kind <= reflect.Uintptr:
+			value, err = strconv.ParseUint(s, 10, 64)
+		case kind == reflect.Float32 || kind == reflect.Float64:
+			value, err = strconv.ParseFloat(s, 64)
+		case kind == reflect.String:
+			value = s
+		default:
+			return nil
+		}
+
+		if err != nil {
+			return nil
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// schemaType returns a JSON Schema type of a basic type.
+func schemaType(typ reflect.Type) string {
+	switch kind := typ.Kind(); {
+	case kind >= reflect.Int && kind <= reflect.Uintptr:
+		return "integer"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "number"
+	case kind == reflect.String:
+		return "string"
+	case kind == reflect.Bool:
+		return "boolean"
+	}
+
+	return ""
+}
+
+// parseJSONTag returns a name and options of the json tag of a field.
+func parseJSONTag(field reflect.StructField) (string, string) {
+	tag := field.Tag.Get("json")
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+
+	return tag, ""
+}
+
+// indirectType dereferences pointer types.
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type Tag struct {
+		Name string `json:"name" validate:"empty=false & lte=10"`
+	}
+
+	type Node struct {
+		Children []*Node `json:"children,omitempty" validate:"lte=3"`
+	}
+
+	type Order struct {
+		ID       string            `json:"id" validate:"format=uuid"`
+		Code     string            `json:"code" validate:"format=alnum"`
+		Qty      int               `json:"qty" validate:"gte=1 & lt=100"`
+		Price    *float64          `json:"price" validate:"nil=false > gt=0"`
+		Status   string            `json:"status" validate:"enum=new,paid"`
+		Emails   []string          `json:"emails" validate:"empty=false & unique > format=email"`
+		Labels   map[string]string `json:"labels" validate:"[lte=8] > empty=false"`
+		Tag      Tag               `json:"tag"`
+		Node     Node              `json:"node"`
+		Skipped  string            `json:"-"`
+		Priority int               `json:"priority" validate:"eq=0 | gte=10"`
+		Zip      string            `json:"zip" validate:"postcode=CN"`
+		internal int
+	}
+
+	schema, err := JSONSchema(Order{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual interface{}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected interface{}
+	if err := json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/Order",
+		"$defs": {
+			"Order": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string", "format": "uuid"},
+					"code": {"type": "string", "pattern": "^[a-zA-Z0-9]+$"},
+					"qty": {"type": "integer", "minimum": 1, "exclusiveMaximum": 100},
+					"price": {"type": "number", "exclusiveMinimum": 0},
+					"status": {"type": "string", "enum": ["new", "paid"]},
+					"emails": {"type": "array", "minItems": 1, "uniqueItems": true, "items": {"type": "string", "format": "email"}},
+					"labels": {
+						"type": "object",
+						"propertyNames": {"maxLength": 8},
+						"additionalProperties": {"type": "string", "minLength": 1}
+					},
+					"tag": {"$ref": "#/$defs/Tag"},
+					"node": {"$ref": "#/$defs/Node"},
+					"priority": {"type": "integer", "anyOf": [{"const": 0}, {"minimum": 10}]},
+					"zip": {"type": "string", "pattern": "^[0-9]{6}$"}
+				},
+				"required": ["price", "emails"]
+			},
+			"Tag": {
+				"type": "object",
+				"properties": {"name": {"type": "string", "minLength": 1, "maxLength": 10}},
+				"required": ["name"]
+			},
+			"Node": {
+				"type": "object",
+				"properties": {"children": {"type": "array", "maxItems": 3, "items": {"$ref": "#/$defs/Node"}}}
+			}
+		}
+	}`), &expected); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("JSONSchema generates unexpected schema %s", data)
+	}
+
+	if _, err := JSONSchema(struct {
+		field int `validate:"gte=a"`
+	}{}); err == nil {
+		t.Errorf("JSONSchema does not report syntax errors")
+	}
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Following validators check rules spanning several fields of a struct.
+// They are usually put on a blank field, which stands for the struct itself, errors are reported for the struct.
+// A field is set if it is not zero, fields are referenced like in cross-field validators.
+//
+//	type Contact struct {
+//		_     struct{} `validate:"one_of_required=Email,Phone & mutually_exclusive=Email,Phone"`
+//		Email string
+//		Phone string
+//	}
+//
+// nolint:lll
+const (
+	// OneOfRequired checks if at least one of the fields is set.
+	// E.g. `validate:"one_of_required=Email,Phone"`
+	OneOfRequired Type = "one_of_required"
+
+	// AllOrNone checks if either all of the fields or none of them are set.
+	// E.g. `validate:"all_or_none=Street,City,Zip"`
+	AllOrNone = "all_or_none"
+
+	// MutuallyExclusive checks if at most one of the fields is set.
+	// E.g. `validate:"mutually_exclusive=Card,Account"`
+	MutuallyExclusive = "mutually_exclusive"
+
+	// AtLeast checks if at least N of the fields are set.
+	// E.g. `validate:"at_least=2 of Email,Phone,Address"`
+	AtLeast = "at_least"
+)
+
+// parseGroupValidator parses the fields of a struct-level validator and the number of at_least.
+// It returns false if the validator is not a struct-level one.
+func parseGroupValidator(validatorType Type, validator string) (fields []string, min int, ok bool, err error) {
+	switch validatorType {
+	case OneOfRequired, AllOrNone, MutuallyExclusive:
+	case AtLeast:
+		parts := strings.SplitN(validator, " of ", 2)
+		if len(parts) != 2 { // nolint:gomnd
+			return nil, 0, true, fmt.Errorf("could not find \" of \" in %q", validator)
+		}
+
+		if min, err = strconv.Atoi(strings.TrimSpace(parts[0])); err != nil || min < 0 {
+			return nil, 0, true, fmt.Errorf("could not parse number %q", parts[0])
+		}
+
+		validator = parts[1]
+	default:
+		return nil, 0, false, nil
+	}
+
+	for _, token := range parseTokens(validator) {
+		fields = append(fields, token.(string))
+	}
+
+	if len(fields) == 0 {
+		return nil, 0, true, fmt.Errorf("could not find fields in %q", validator)
+	}
+
+	return fields, min, true, nil
+}
+
+// groupValidator creates a struct-level validator, accept gets the number of set fields,
+// the number of all fields and the number of at_least.
+func groupValidator(validatorType Type, accept func(n, total, min int) bool) crossFieldValidatorFunc {
+	return func(value reflect.Value, validator string, parents []reflect.Value) ErrorField {
+		errorSyntax := ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+
+		fields, min, _, err := parseGroupValidator(validatorType, validator)
+		if err != nil || len(parents) == 0 {
+			return errorSyntax
+		}
+
+		var set []string
+
+		for _, field := range fields {
+			other, err := resolveField(parents, field)
+			if err != nil {
+				return errorSyntax
+			}
+
+			if !isZero(other) {
+				set = append(set, field)
+			}
+		}
+
+		if !accept(len(set), len(fields), min) {
+			cause := fmt.Errorf("no field is set")
+			if len(set) > 0 {
+				cause = fmt.Errorf("set fields: %s", strings.Join(set, ", "))
+			}
+
+			return ErrorValidation{
+				fieldValue:     parents[len(parents)-1],
+				validatorType:  validatorType,
+				validatorValue: validator,
+				err:            cause,
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"testing"
+)
+
+type contact struct {
+	_       struct{} `validate:"one_of_required=Email,Phone & mutually_exclusive=Email,Phone"`
+	Email   string
+	Phone   string
+	Address *address
+}
+
+type address struct {
+	_      struct{} `validate:"all_or_none=Street,City"`
+	Street string
+	City   string
+}
+
+func TestOneOfRequiredVal(t *testing.T) {
+	if nil != Validate(contact{Email: "a@b.c"}) {
+		t.Errorf("one_of_required validator does not validate")
+	}
+
+	if nil != Validate(contact{Phone: "123"}) {
+		t.Errorf("one_of_required validator does not validate")
+	}
+
+	err := Validate(contact{})
+	if err == nil {
+		t.Fatalf("one_of_required validator does not validate")
+	}
+
+	expected := `Validation error in value of type "validate.contact" using validator "one_of_required=Email,Phone": no field is set`
+	if err.Error() != expected {
+		t.Errorf("one_of_required validator reports %q, expected %q", err.Error(), expected)
+	}
+}
+
+func TestMutuallyExclusiveVal(t *testing.T) {
+	err := Validate(contact{Email: "a@b.c", Phone: "123"})
+	if err == nil {
+		t.Fatalf("mutually_exclusive validator does not validate")
+	}
+
+	option, _ := New(Locale(LocaleEn))
+	if err := option.Validate(contact{Email: "a@b.c", Phone: "123"}); err == nil {
+		t.Errorf("mutually_exclusive validator does not validate")
+	} else if err.Error() != "only one of Email,Phone could be set" {
+		t.Errorf("mutually_exclusive validator reports %q", err.Error())
+	}
+}
+
+func TestAllOrNoneVal(t *testing.T) {
+	if nil != Validate(contact{Email: "a@b.c", Address: &address{}}) {
+		t.Errorf("all_or_none validator does not validate")
+	}
+
+	if nil != Validate(contact{Email: "a@b.c", Address: &address{Street: "Main", City: "Beijing"}}) {
+		t.Errorf("all_or_none validator does not validate")
+	}
+
+	err := Validate(contact{Email: "a@b.c", Address: &address{City: "Beijing"}})
+	if err == nil {
+		t.Fatalf("all_or_none validator does not validate")
+	}
+
+	if path := err.(ErrorField).FieldPath(); path != "Address" {
+		t.Errorf("all_or_none validator reports path %q", path)
+	}
+}
+
+func TestAtLeastVal(t *testing.T) {
+	type St struct {
+		_       struct{} `validate:"at_least=2 of Email,Phone,Address.City"`
+		Email   string
+		Phone   *string
+		Address *address
+	}
+
+	phone := "123"
+
+	if nil != Validate(St{Email: "a@b.c", Phone: &phone}) {
+		t.Errorf("at_least validator does not validate")
+	}
+
+	if nil != Validate(St{Phone: &phone, Address: &address{Street: "Main", City: "Beijing"}}) {
+		t.Errorf("at_least validator does not validate")
+	}
+
+	if nil == Validate(St{Email: "a@b.c", Address: &address{}}) {
+		t.Errorf("at_least validator does not validate")
+	}
+
+	if err := Compile(contact{}); err != nil {
+		t.Errorf("struct-level validators do not compile: %v", err)
+	}
+
+	if err := Compile(struct {
+		_ struct{} `validate:"at_least=two of Email"`
+	}{}); err == nil {
+		t.Errorf("at_least validator does not report a syntax error")
+	}
+
+	if err := Compile(struct {
+		_     struct{} `validate:"one_of_required=Email,Phone"`
+		Email string
+	}{}); err == nil {
+		t.Errorf("one_of_required validator does not report a missing field")
+	}
+}
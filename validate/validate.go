@@ -1,6 +1,8 @@
 package validate
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
@@ -16,9 +18,35 @@ type Validator interface {
 	Validate() error
 }
 
+// ValidatorWithContext is an interface for a validated struct, which needs the context of ValidateContext.
+// It takes precedence over Validator.
+type ValidatorWithContext interface {
+	// ValidateContext is a custom validation function, see Validator.
+	ValidateContext(ctx context.Context) error
+}
+
 // Option is the options for Validate.
 type Option struct {
 	TagName string `default:"validate"`
+	// CollectAll walks the whole value and returns all errors as Errors instead of stopping at the first one.
+	CollectAll bool
+	// Locale is the locale of error messages, see Messages.
+	Locale string
+	// MessageTagName is the tag name for custom error messages of a field, e.g. `msg:"{field} is too short"`.
+	MessageTagName string `default:"msg"`
+	// FieldNameTag is the tag name to take field names reported in errors from, e.g. "json".
+	// Go field names are reported when it is empty or the tag has no name.
+	FieldNameTag string
+	// Groups are the active validation groups, see Groups.
+	Groups []string
+	// GroupsTagName is the tag name to limit a field to the given groups, e.g. `groups:"create"`.
+	GroupsTagName string `default:"groups"`
+	// PartialHooks makes Partial call custom validators on the way to the selected paths, see PartialHooks.
+	PartialHooks bool
+	// ModTagName is the tag name for modifiers applied to strings before validation, e.g. `mod:"trim & lower"`.
+	ModTagName string `default:"mod"`
+
+	registry registry
 }
 
 // OptionFn is the function prototype to apply option
@@ -27,6 +55,12 @@ type OptionFn func(*Option)
 // TagName defines the tag name for validate.
 func TagName(tagName string) OptionFn { return func(o *Option) { o.TagName = tagName } }
 
+// FieldNameTag defines the tag name to take field names reported in errors from, e.g. "json", "form" or "yaml".
+func FieldNameTag(tagName string) OptionFn { return func(o *Option) { o.FieldNameTag = tagName } }
+
+// CollectAll makes Validate return all errors as Errors instead of the first one.
+func CollectAll() OptionFn { return func(o *Option) { o.CollectAll = true } }
+
 // Validate validates fields of a struct.
 // It accepts a struct or a struct pointer as a parameter.
 // It returns an error if a struct does not validate or nil if there are no validation errors.
@@ -39,14 +73,46 @@ func TagName(tagName string) OptionFn { return func(o *Option) { o.TagName = tag
 //
 //	// err contains an error
 func Validate(element interface{}, optionFns ...OptionFn) error {
+	return ValidateContext(context.Background(), element, optionFns...)
+}
+
+// ValidateContext validates fields of a struct like Validate.
+// The context is passed to custom validators implementing ValidatorWithContext.
+// Validation stops with the context error when the context is done.
+func ValidateContext(ctx context.Context, element interface{}, optionFns ...OptionFn) error {
 	option, err := createOption(optionFns)
 	if err != nil {
 		return err
 	}
 
-	value := reflect.ValueOf(element)
+	return option.ValidateContext(ctx, element)
+}
 
-	return option.validateField(value, "", "")
+// validation keeps the state of a single validation.
+type validation struct {
+	*Option
+	ctx context.Context
+}
+
+// New creates an option, which could keep its own custom validators and formats.
+func New(optionFns ...OptionFn) (*Option, error) {
+	return createOption(optionFns)
+}
+
+// Validate validates an element with the option, see Validate function.
+func (o *Option) Validate(element interface{}) error {
+	return o.ValidateContext(context.Background(), element)
+}
+
+// ValidateContext validates an element with the option, see ValidateContext function.
+func (o *Option) ValidateContext(ctx context.Context, element interface{}) error {
+	if len(o.Groups) > 0 {
+		ctx = context.WithValue(ctx, groupsKey{}, o.Groups)
+	}
+
+	v := &validation{Option: o, ctx: ctx}
+
+	return v.validateField(reflect.ValueOf(element), "", compileExpression(""), nil, "", nil)
 }
 
 func createOption(optionFns []OptionFn) (*Option, error) {
@@ -62,37 +128,69 @@ func createOption(optionFns []OptionFn) (*Option, error) {
 	return option, nil
 }
 
-// validateField validates a struct field
-// nolint:gocognit
-func (o *Option) validateField(value reflect.Value, fieldName string, validators string) error {
+// validateField validates a struct field.
+// parents contains the struct of the field and all structs above it, the nearest last.
+// message is a custom message template of the field.
+// selected contains paths selected by Partial below the field, nil means the whole field.
+// nolint:gocognit,funlen,gocyclo
+func (v *validation) validateField(value reflect.Value, fieldName string, expr *expression,
+	parents []reflect.Value, message string, selected *pathNode) error {
+	// Validate the dynamic value of an interface, e.g. an element of []interface{}
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+
 	kind := value.Kind()
 
-	// Get validator type Map
-	validatorTypeMap := getValidatorTypeMap()
+	if expr.err != nil {
+		return setFieldName(expr.err, fieldName)
+	}
+
+	// Validators after a guard are checked only if its condition is true
+	if expr.guard != nil {
+		ok, err := expr.guard.eval(parents)
+		if err != nil {
+			return setFieldName(err, fieldName)
+		}
 
-	// Get validators
-	keyValidators, valueValidators, validators, err := splitValidators(validators)
-	if err != nil {
-		err = setFieldName(err, fieldName)
-		return err
+		if ok {
+			expr = expr.then
+		} else {
+			expr = emptyExpression
+		}
 	}
 
+	var errs Errors
+
+	// Only paths below the field are selected, validators of the field itself are skipped
+	partial := selected != nil && !selected.all
+
 	// Call a custom validator
-	if err := callCustomValidator(value); err != nil {
-		return err
-	}
+	if !partial || v.PartialHooks {
+		if err := callCustomValidator(v.ctx, value); err != nil {
+			if !v.CollectAll {
+				return err
+			}
 
-	// Parse validators
-	validatorsOr, err := parseValidators(valueValidators)
-	if err != nil {
-		err = setFieldName(err, fieldName)
-		return err
+			errs = errs.append(ErrorCustom{fieldName: fieldName, err: err})
+		}
 	}
 
 	// Perform validators
-	for _, validatorsAnd := range validatorsOr {
+	var err ErrorField
+
+	for _, validatorsAnd := range expr.validatorsOr {
+		if partial {
+			break
+		}
+
 		for _, validator := range validatorsAnd {
-			if validatorFunc, ok := validatorTypeMap[validator.Type]; ok {
+			if crossFieldValidatorFunc, ok := getCrossFieldValidatorTypeMap()[validator.Type]; ok {
+				if err = crossFieldValidatorFunc(value, validator.Value, parents); err != nil {
+					err = setFieldName(err, fieldName)
+					break
+				}
+			} else if validatorFunc, ok := v.lookupValidator(validator.Type); ok {
 				if err = validatorFunc(value, validator.Value); err != nil {
 					err = setFieldName(err, fieldName)
 					break
@@ -101,7 +199,7 @@ func (o *Option) validateField(value reflect.Value, fieldName string, validators
 				return ErrorSyntax{
 					fieldName:  fieldName,
 					expression: string(validator.Type),
-					near:       valueValidators,
+					near:       expr.validators,
 					comment:    "could not find a validator",
 				}
 			}
@@ -112,43 +210,85 @@ func (o *Option) validateField(value reflect.Value, fieldName string, validators
 	}
 
 	if err != nil {
-		return err
+		err = v.setMessage(err, message)
+
+		if !v.CollectAll {
+			return err
+		}
+
+		errs = errs.append(err)
 	}
 
 	// Dive one level deep into arrays and pointers
 	switch kind {
 	case reflect.Struct:
-		if err := o.validateStruct(value); err != nil {
-			return err
+		if err := v.validateStruct(value, parents, selected); err != nil {
+			if !v.CollectAll {
+				return err
+			}
+
+			errs = errs.append(err)
 		}
 	case reflect.Map:
 		for _, key := range value.MapKeys() {
-			if err := o.validateField(key, fieldName, keyValidators); err != nil {
+			if err := v.ctx.Err(); err != nil {
 				return err
 			}
-			if err := o.validateField(value.MapIndex(key), fieldName, validators); err != nil {
-				return err
+
+			segment := mapKeySegment(key)
+			elemSelected := selected.child(segment)
+
+			if err := v.validateField(key, fieldName, expr.key, parents, message, elemSelected); err != nil {
+				if !v.CollectAll {
+					return withPath(err, segment)
+				}
+
+				errs = errs.append(withPath(err, segment))
+			}
+			if err := v.validateField(value.MapIndex(key), fieldName, expr.elem, parents, message, elemSelected); err != nil {
+				if !v.CollectAll {
+					return withPath(err, segment)
+				}
+
+				errs = errs.append(withPath(err, segment))
 			}
 		}
 	case reflect.Slice, reflect.Array:
 		for i := 0; i < value.Len(); i++ {
-			if err := o.validateField(value.Index(i), fieldName, validators); err != nil {
+			if err := v.ctx.Err(); err != nil {
 				return err
 			}
+
+			elemSelected := selected
+			if selected != nil {
+				elemSelected = selected.child(fmt.Sprintf("[%d]", i))
+			}
+
+			if err := v.validateField(value.Index(i), fieldName, expr.elem, parents, message, elemSelected); err != nil {
+				if !v.CollectAll {
+					return withPath(err, fmt.Sprintf("[%d]", i))
+				}
+
+				errs = errs.append(withPath(err, fmt.Sprintf("[%d]", i)))
+			}
 		}
 	case reflect.Ptr:
 		if !value.IsNil() {
-			if err := o.validateField(value.Elem(), fieldName, validators); err != nil {
-				return err
+			if err := v.validateField(value.Elem(), fieldName, expr.elem, parents, message, selected); err != nil {
+				if !v.CollectAll {
+					return err
+				}
+
+				errs = errs.append(err)
 			}
 		}
 	}
 
 	if kind != reflect.Map {
-		if len(keyValidators) > 0 {
+		if len(expr.keys) > 0 {
 			return ErrorSyntax{
 				fieldName:  fieldName,
-				expression: validators,
+				expression: expr.keys,
 				near:       "",
 				comment:    "unexpexted expression",
 			}
@@ -156,34 +296,64 @@ func (o *Option) validateField(value reflect.Value, fieldName string, validators
 	}
 
 	if kind != reflect.Map && kind != reflect.Slice && kind != reflect.Array && kind != reflect.Ptr {
-		if len(validators) > 0 {
+		if len(expr.elems) > 0 {
 			return ErrorSyntax{
 				fieldName:  fieldName,
-				expression: validators,
+				expression: expr.elems,
 				near:       "",
 				comment:    "unexpexted expression",
 			}
 		}
 	}
 
-	return nil
+	return errs.errorOrNil()
 }
 
 // validateStruct validates a struct
-func (o *Option) validateStruct(value reflect.Value) error {
-	typ := value.Type()
+func (v *validation) validateStruct(value reflect.Value, parents []reflect.Value, selected *pathNode) error {
+	parents = append(parents[:len(parents):len(parents)], value)
+
+	plan := v.getStructPlan(value.Type())
+
+	// Modifiers of all fields are applied first, so cross-field validators see modified values
+	for _, f := range plan.fields {
+		if err := v.modifyField(value.Field(f.index), f.name, f.mod, parents, nil); err != nil {
+			return withPath(err, f.name)
+		}
+	}
+
+	var errs Errors
 
 	// Iterate over struct fields
-	for i := 0; i < typ.NumField(); i++ {
-		validators := o.getValidators(typ.Field(i).Tag)
-		fieldName := typ.Field(i).Name
+	for _, f := range plan.fields {
+		fieldSelected := selected.field(f.name)
+		if selected != nil && fieldSelected == nil {
+			continue
+		}
 
-		if err := o.validateField(value.Field(i), fieldName, validators); err != nil {
-			return err
+		if err := v.validateField(value.Field(f.index), f.name, f.expr, parents, f.message, fieldSelected); err != nil {
+			if !v.CollectAll {
+				return withPath(err, f.name)
+			}
+
+			errs = errs.append(withPath(err, f.name))
 		}
 	}
 
-	return nil
+	return errs.errorOrNil()
+}
+
+// mapKeySegment formats a map key as a path segment, e.g. ["env"] or [3].
+func mapKeySegment(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return fmt.Sprintf("[%q]", key.String())
+	}
+
+	if key.CanInterface() {
+		return fmt.Sprintf("[%v]", key.Interface())
+	}
+
+	return fmt.Sprintf("[%v]", key)
 }
 
 // getValidators gets validators
@@ -274,13 +444,16 @@ loop:
 	return
 }
 
+// nolint:gochecknoglobals
+var (
+	regexpType  = regexp.MustCompile(`[[:alnum:]_]+`)
+	regexpValue = regexp.MustCompile(`[^=\s]+[^=]*[^=\s]+|[^=\s]+`)
+)
+
 // parseValidator parses validators into the slice of slices.
 // First slice acts as AND logic, second array acts as OR logic.
 func parseValidators(validators string) (validatorsOr [][]validator, err ErrorField) {
-	regexpType := regexp.MustCompile(`[[:alnum:]_]+`)
-	regexpValue := regexp.MustCompile(`[^=\s]+[^=]*[^=\s]+|[^=\s]+`)
-
-	if len(validators) == 0 {
+	if len(strings.TrimSpace(validators)) == 0 {
 		return
 	}
 
@@ -356,15 +529,27 @@ func tokenOneOf(token interface{}, tokens []interface{}) bool {
 	return false
 }
 
+// nolint:gochecknoglobals
+var (
+	validatorInterface            = reflect.TypeOf((*Validator)(nil)).Elem()
+	validatorWithContextInterface = reflect.TypeOf((*ValidatorWithContext)(nil)).Elem()
+)
+
 // Call a custom validator
-func callCustomValidator(value reflect.Value) error {
+func callCustomValidator(ctx context.Context, value reflect.Value) error {
 	if !value.CanInterface() {
 		return nil
 	}
 
+	// Skip types which implement the validator neither by value nor by reference without boxing the value
+	if typ := value.Type(); typ.Kind() != reflect.Interface &&
+		!implementsValidator(typ) && !implementsValidator(reflect.PtrTo(typ)) {
+		return nil
+	}
+
 	// Following code won't work in case if Validate is implemented by reference and value is passed by value
-	if customValidator, ok := value.Interface().(Validator); ok {
-		return customValidator.Validate()
+	if ok, err := callValidator(ctx, value.Interface()); ok {
+		return err
 	}
 
 	// Following code is a fallback if value is passed by value
@@ -372,9 +557,25 @@ func callCustomValidator(value reflect.Value) error {
 
 	valueCopyPointer.Elem().Set(value)
 
-	if customValidator, ok := valueCopyPointer.Interface().(Validator); ok {
-		return customValidator.Validate()
+	_, err := callValidator(ctx, valueCopyPointer.Interface())
+
+	return err
+}
+
+// implementsValidator checks if a type implements Validator or ValidatorWithContext.
+func implementsValidator(typ reflect.Type) bool {
+	return typ.Implements(validatorWithContextInterface) || typ.Implements(validatorInterface)
+}
+
+// callValidator calls ValidatorWithContext or Validator, it returns false if v implements neither.
+func callValidator(ctx context.Context, v interface{}) (bool, error) {
+	if customValidator, ok := v.(ValidatorWithContext); ok {
+		return true, customValidator.ValidateContext(ctx)
+	}
+
+	if customValidator, ok := v.(Validator); ok {
+		return true, customValidator.Validate()
 	}
 
-	return nil
+	return false, nil
 }
@@ -2,8 +2,10 @@
 package validate
 
 import (
+	"context"
 	"errors"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -5180,3 +5182,305 @@ func TestDeepDeepStructVal(t *testing.T) {
 		t.Errorf("complex validator does not validate")
 	}
 }
+
+func TestCollectAll(t *testing.T) {
+	type Item struct {
+		Qty  int               `validate:"gte=1"`
+		Tags map[string]string `validate:"> empty=false"`
+	}
+
+	type Order struct {
+		Name  string `validate:"empty=false"`
+		Items []Item `validate:"empty=false"`
+	}
+
+	order := Order{
+		Items: []Item{
+			{Qty: 1, Tags: map[string]string{"env": "prod"}},
+			{Qty: 0, Tags: map[string]string{"env": ""}},
+		},
+	}
+
+	err := Validate(order)
+	if _, ok := err.(ErrorValidation); !ok {
+		t.Errorf("validate does not stop at the first error without CollectAll")
+	}
+
+	err = Validate(order, CollectAll())
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("validate does not return Errors with CollectAll")
+	}
+
+	paths := make([]string, 0, len(errs))
+	for _, e := range errs {
+		paths = append(paths, e.FieldPath())
+	}
+
+	if !reflect.DeepEqual(paths, []string{"Name", "Items[1].Qty", `Items[1].Tags["env"]`}) {
+		t.Errorf("validate collects wrong field paths %v", paths)
+	}
+
+	var errValidation ErrorValidation
+	if !errors.As(err, &errValidation) || errValidation.FieldName() != "Name" {
+		t.Errorf("errors.As does not find an entry of Errors")
+	}
+
+	if nil != Validate(Order{Name: "a", Items: []Item{{Qty: 1}}}, CollectAll()) {
+		t.Errorf("validate with CollectAll does not validate")
+	}
+}
+
+func TestCollectAllCustomValidator(t *testing.T) {
+	err := Validate([]StCustomValidator{{field: 1}, {field: 0, anotherField: 1}}, CollectAll())
+
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("validate does not collect custom validator errors")
+	}
+
+	if _, ok := errs[0].(ErrorCustom); !ok || errs[0].FieldPath() != "[1]" {
+		t.Errorf("validate does not wrap custom validator error %v", errs[0])
+	}
+
+	if errs[1].FieldPath() != "[1].anotherField" {
+		t.Errorf("validate collects wrong field path %v", errs[1].FieldPath())
+	}
+}
+
+func TestFieldNameTag(t *testing.T) {
+	type Base struct {
+		ID int `json:"id" validate:"gte=1"`
+	}
+
+	type Item struct {
+		Qty  int               `json:"qty,omitempty" validate:"gte=1"`
+		Tags map[string]string `json:"-" validate:"> empty=false"`
+	}
+
+	type Order struct {
+		Base
+		Items []Item `json:"items" form:"item"`
+	}
+
+	order := Order{
+		Items: []Item{
+			{Qty: 1, Tags: map[string]string{"env": "prod"}},
+			{Qty: 0, Tags: map[string]string{"env": ""}},
+		},
+	}
+
+	tests := []struct {
+		options  []OptionFn
+		expected []string
+	}{
+		{nil, []string{"Base.ID", "Items[1].Qty", `Items[1].Tags["env"]`}},
+		{[]OptionFn{FieldNameTag("json")}, []string{"id", "items[1].qty", `items[1].Tags["env"]`}},
+		{[]OptionFn{FieldNameTag("form")}, []string{"ID", "item[1].Qty", `item[1].Tags["env"]`}},
+	}
+
+	for _, test := range tests {
+		errs, _ := Validate(order, append(test.options, CollectAll())...).(Errors)
+
+		paths := make([]string, 0, len(errs))
+		for _, e := range errs {
+			paths = append(paths, e.FieldPath())
+		}
+
+		if !reflect.DeepEqual(paths, test.expected) {
+			t.Errorf("validate reports field paths %v, expected %v", paths, test.expected)
+		}
+	}
+
+	if err := Validate(Item{}, FieldNameTag("json")); err == nil || err.(ErrorField).FieldName() != "qty" {
+		t.Errorf("validate does not report field name from tag")
+	}
+}
+
+type ctxKey struct{}
+
+type StContextValidator struct {
+	field int
+}
+
+func (st StContextValidator) ValidateContext(ctx context.Context) error {
+	if limit, _ := ctx.Value(ctxKey{}).(int); st.field > limit {
+		return errors.New("field is over the limit")
+	}
+
+	return nil
+}
+
+func (st StContextValidator) Validate() error {
+	return errors.New("Validate should not be called")
+}
+
+func TestValidateContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, 10)
+
+	if nil != ValidateContext(ctx, struct {
+		Items []StContextValidator
+	}{
+		Items: []StContextValidator{{field: 1}, {field: 10}},
+	}) {
+		t.Errorf("context validator does not validate")
+	}
+
+	err := ValidateContext(ctx, map[string]*StContextValidator{"a": {field: 11}})
+	if err == nil || err.Error() != "field is over the limit" {
+		t.Errorf("context validator does not validate nested values: %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if err := ValidateContext(canceled, []int{1, 2}, CollectAll()); err != context.Canceled {
+		t.Errorf("validate does not stop when the context is done: %v", err)
+	}
+}
+
+func TestRunesVal(t *testing.T) {
+	type User struct {
+		Name string `validate:"gte=6 & runes_lte=3"`
+	}
+
+	if nil != Validate(User{Name: "张三丰"}) {
+		t.Errorf("runes_lte validator does not validate")
+	}
+
+	if nil == Validate(User{Name: "张三丰x"}) {
+		t.Errorf("runes_lte validator does not validate")
+	}
+
+	if nil == Validate(struct {
+		Names []string `validate:"> runes_gte=2 & runes_lt=4"`
+	}{
+		Names: []string{"张三", "李"},
+	}) {
+		t.Errorf("runes_gte validator does not validate slice elements")
+	}
+
+	if _, ok := Validate(struct {
+		field int `validate:"runes_eq=1"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("runes_eq validator does not return syntax error for int")
+	}
+}
+
+func TestStringVal(t *testing.T) {
+	type Item struct {
+		Code  string            `validate:"prefix=SKU- & excludes=_"`
+		File  string            `validate:"suffix=.png | suffix=.jpg"`
+		Email string            `validate:"contains=@"`
+		Tags  map[string]string `validate:"[prefix=x-] > excludes=secret"`
+	}
+
+	valid := Item{Code: "SKU-1", File: "a.jpg", Email: "a@b", Tags: map[string]string{"x-env": "prod"}}
+	if nil != Validate(valid) {
+		t.Errorf("string validators do not validate")
+	}
+
+	for _, item := range []Item{
+		{Code: "1", File: "a.jpg", Email: "a@b"},
+		{Code: "SKU-_", File: "a.jpg", Email: "a@b"},
+		{Code: "SKU-1", File: "a.gif", Email: "a@b"},
+		{Code: "SKU-1", File: "a.jpg", Email: "ab"},
+		{Code: "SKU-1", File: "a.jpg", Email: "a@b", Tags: map[string]string{"env": "prod"}},
+		{Code: "SKU-1", File: "a.jpg", Email: "a@b", Tags: map[string]string{"x-env": "a secret"}},
+	} {
+		if nil == Validate(item) {
+			t.Errorf("string validators do not validate %+v", item)
+		}
+	}
+}
+
+func TestRegexVal(t *testing.T) {
+	RegisterRegex("sku", regexp.MustCompile(`^SKU-\d+$`))
+
+	type Item struct {
+		Codes []string `validate:"> regex=sku"`
+	}
+
+	if nil != Validate(Item{Codes: []string{"SKU-1", "SKU-22"}}) {
+		t.Errorf("regex validator does not validate")
+	}
+
+	if nil == Validate(Item{Codes: []string{"SKU-1", "SKU-x"}}) {
+		t.Errorf("regex validator does not validate")
+	}
+
+	if _, ok := Validate(struct {
+		Code string `validate:"regex=unknown"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("regex validator does not return syntax error for unknown regex")
+	}
+
+	option, _ := New()
+	option.RegisterRegex("upper", regexp.MustCompile(`^[A-Z]+$`))
+
+	if nil == option.Validate(struct {
+		Code string `validate:"regex=upper"`
+	}{Code: "a"}) {
+		t.Errorf("regex validator does not validate with option regex")
+	}
+}
+
+func TestTimeVal(t *testing.T) {
+	type St struct {
+		Since time.Time `validate:"gte=now-24h & lt=now+1m"`
+		Born  time.Time `validate:"gte=1900-01-01 & lte=2100-01-01T00:00:00Z"`
+	}
+
+	now := time.Now()
+
+	if nil != Validate(St{Since: now.Add(-time.Hour), Born: now}) {
+		t.Errorf("time validator does not validate")
+	}
+
+	if nil == Validate(St{Since: now.Add(-48 * time.Hour), Born: now}) {
+		t.Errorf("time validator does not validate")
+	}
+
+	if nil == Validate(St{Since: now, Born: time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC)}) {
+		t.Errorf("time validator does not validate")
+	}
+
+	if err := Validate(struct {
+		At time.Time `validate:"gte=yesterday"`
+	}{}); err == nil {
+		t.Errorf("time validator does not validate")
+	} else if _, ok := err.(ErrorSyntax); !ok {
+		t.Errorf("time validator does not report a syntax error")
+	}
+
+	type unexported struct {
+		born time.Time `validate:"gte=2020-01-01"`
+	}
+
+	if err := Validate(unexported{born: now}); err != nil {
+		t.Errorf("time validator does not validate unexported fields: %v", err)
+	}
+
+	if _, ok := Validate(&unexported{born: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)}).(ErrorValidation); !ok {
+		t.Errorf("time validator does not validate unexported fields")
+	}
+}
+
+func TestDatetimeVal(t *testing.T) {
+	type St struct {
+		Day string `validate:"datetime=2006-01-02 15:04"`
+	}
+
+	if nil != Validate(St{Day: "2020-02-29 13:30"}) {
+		t.Errorf("datetime validator does not validate")
+	}
+
+	if nil == Validate(St{Day: "2020-02-30 13:30"}) {
+		t.Errorf("datetime validator does not validate")
+	}
+
+	if nil == Validate(St{Day: "2020-02-29"}) {
+		t.Errorf("datetime validator does not validate")
+	}
+}
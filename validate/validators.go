@@ -2,8 +2,11 @@ package validate
 
 import (
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Type is used for validator type definitions.
@@ -51,24 +54,87 @@ const (
 	// Format checks if a string of a given format.
 	// E.g. `validate:"format=email"`
 	Format = "format"
+
+	// RunesEq compares a count of characters (runes) in a string, unlike eq which compares a count of bytes.
+	// E.g. `validate:"runes_eq=2"`
+	RunesEq = "runes_eq"
+
+	// RunesGt compares a count of characters (runes) in a string, unlike gt which compares a count of bytes.
+	// E.g. `validate:"runes_gt=0"`
+	RunesGt = "runes_gt"
+
+	// RunesLt compares a count of characters (runes) in a string, unlike lt which compares a count of bytes.
+	// E.g. `validate:"runes_lt=11"`
+	RunesLt = "runes_lt"
+
+	// RunesGte compares a count of characters (runes) in a string, unlike gte which compares a count of bytes.
+	// E.g. `validate:"runes_gte=2"`
+	RunesGte = "runes_gte"
+
+	// RunesLte compares a count of characters (runes) in a string, unlike lte which compares a count of bytes.
+	// E.g. `validate:"runes_lte=10"`
+	RunesLte = "runes_lte"
+
+	// Prefix checks if a string starts with the given text.
+	// E.g. `validate:"prefix=SKU-"`
+	Prefix = "prefix"
+
+	// Suffix checks if a string ends with the given text.
+	// E.g. `validate:"suffix=.png"`
+	Suffix = "suffix"
+
+	// Contains checks if a string contains the given text.
+	// E.g. `validate:"contains=@"`
+	Contains = "contains"
+
+	// Excludes checks if a string does not contain the given text.
+	// E.g. `validate:"excludes=admin"`
+	Excludes = "excludes"
+
+	// Regex checks if a string matches a regular expression registered by RegisterRegex.
+	// E.g. `validate:"regex=sku"`
+	Regex = "regex"
+
+	// Datetime checks if a string is a date and time in the given layout, see time.Parse.
+	// E.g. `validate:"datetime=2006-01-02 15:04"`
+	Datetime = "datetime"
 )
 
 // validatorFunc is an interface for validator func
 type validatorFunc func(value reflect.Value, validator string) ErrorField
 
+// nolint:gochecknoglobals
+var validatorTypeMap = map[Type]validatorFunc{
+	Eq:       validateEq,
+	Ne:       validateNe,
+	Gt:       validateGt,
+	Lt:       validateLt,
+	Gte:      validateGte,
+	Lte:      validateLte,
+	Empty:    validateEmpty,
+	Nil:      validateNil,
+	Enum:     validateOneOf,
+	Format:   validateFormat,
+	RunesEq:  validateRunes(RunesEq, func(n, token int) bool { return n == token }),
+	RunesGt:  validateRunes(RunesGt, func(n, token int) bool { return n > token }),
+	RunesLt:  validateRunes(RunesLt, func(n, token int) bool { return n < token }),
+	RunesGte: validateRunes(RunesGte, func(n, token int) bool { return n >= token }),
+	RunesLte: validateRunes(RunesLte, func(n, token int) bool { return n <= token }),
+	Prefix:   validateString(Prefix, strings.HasPrefix),
+	Suffix:   validateString(Suffix, strings.HasSuffix),
+	Contains: validateString(Contains, strings.Contains),
+	Excludes: validateString(Excludes, func(s, substr string) bool { return !strings.Contains(s, substr) }),
+	Regex:    validateRegex,
+	Datetime: validateDatetime,
+	Postcode: validatePostcode,
+
+	Unique:         validateUnique,
+	Sorted:         validateSorted,
+	DistinctValues: validateDistinctValues,
+}
+
 func getValidatorTypeMap() map[Type]validatorFunc {
-	return map[Type]validatorFunc{
-		Eq:     validateEq,
-		Ne:     validateNe,
-		Gt:     validateGt,
-		Lt:     validateLt,
-		Gte:    validateGte,
-		Lte:    validateLte,
-		Empty:  validateEmpty,
-		Nil:    validateNil,
-		Enum:   validateOneOf,
-		Format: validateFormat,
-	}
+	return validatorTypeMap
 }
 
 type validator struct {
@@ -126,6 +192,12 @@ func validateEq(value reflect.Value, validator string) ErrorField {
 		} else if value.Len() != token {
 			return errorValidation
 		}
+	case reflect.Struct:
+		if c, ok := compareTimeToken(value, validator); !ok {
+			return errorSyntax
+		} else if c != 0 {
+			return errorValidation
+		}
 	default:
 		return errorSyntax
 	}
@@ -183,6 +255,12 @@ func validateNe(value reflect.Value, validator string) ErrorField {
 		} else if value.Len() == token {
 			return errorValidation
 		}
+	case reflect.Struct:
+		if c, ok := compareTimeToken(value, validator); !ok {
+			return errorSyntax
+		} else if c == 0 {
+			return errorValidation
+		}
 	default:
 		return errorSyntax
 	}
@@ -240,6 +318,12 @@ func validateGt(value reflect.Value, validator string) ErrorField {
 		} else if value.Len() <= token {
 			return errorValidation
 		}
+	case reflect.Struct:
+		if c, ok := compareTimeToken(value, validator); !ok {
+			return errorSyntax
+		} else if c <= 0 {
+			return errorValidation
+		}
 	default:
 		return errorSyntax
 	}
@@ -297,6 +381,12 @@ func validateLt(value reflect.Value, validator string) ErrorField {
 		} else if value.Len() >= token {
 			return errorValidation
 		}
+	case reflect.Struct:
+		if c, ok := compareTimeToken(value, validator); !ok {
+			return errorSyntax
+		} else if c >= 0 {
+			return errorValidation
+		}
 	default:
 		return errorSyntax
 	}
@@ -354,6 +444,12 @@ func validateGte(value reflect.Value, validator string) ErrorField {
 		} else if value.Len() < token {
 			return errorValidation
 		}
+	case reflect.Struct:
+		if c, ok := compareTimeToken(value, validator); !ok {
+			return errorSyntax
+		} else if c < 0 {
+			return errorValidation
+		}
 	default:
 		return errorSyntax
 	}
@@ -411,6 +507,12 @@ func validateLte(value reflect.Value, validator string) ErrorField {
 		} else if value.Len() > token {
 			return errorValidation
 		}
+	case reflect.Struct:
+		if c, ok := compareTimeToken(value, validator); !ok {
+			return errorSyntax
+		} else if c > 0 {
+			return errorValidation
+		}
 	default:
 		return errorSyntax
 	}
@@ -604,6 +706,14 @@ func validateOneOf(value reflect.Value, validator string) ErrorField {
 }
 
 func validateFormat(value reflect.Value, validator string) ErrorField {
+	return validateFormatWith(value, validator, func(formatType FormatType) (FormatFunc, bool) {
+		formatFunc, ok := getFormatTypeMap()[formatType]
+		return formatFunc, ok
+	})
+}
+
+// validateFormatWith validates a format found by lookupFormat.
+func validateFormatWith(value reflect.Value, validator string, lookupFormat func(FormatType) (FormatFunc, bool)) ErrorField {
 	kind := value.Kind()
 
 	errorValidation := ErrorValidation{
@@ -620,8 +730,7 @@ func validateFormat(value reflect.Value, validator string) ErrorField {
 
 	switch kind {
 	case reflect.String:
-		formatTypeMap := getFormatTypeMap()
-		if formatFunc, ok := formatTypeMap[FormatType(validator)]; !ok {
+		if formatFunc, ok := lookupFormat(FormatType(validator)); !ok {
 			return errorSyntax
 		} else if !formatFunc(value.String()) {
 			return errorValidation
@@ -632,3 +741,166 @@ func validateFormat(value reflect.Value, validator string) ErrorField {
 
 	return nil
 }
+
+// validateRunes creates a validator comparing a count of runes in a string.
+func validateRunes(validatorType Type, accept func(n, token int) bool) validatorFunc {
+	return func(value reflect.Value, validator string) ErrorField {
+		errorValidation := ErrorValidation{
+			fieldValue:     value,
+			validatorType:  validatorType,
+			validatorValue: validator,
+		}
+
+		errorSyntax := ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			if token, err := strconv.Atoi(validator); err != nil {
+				return errorSyntax
+			} else if !accept(utf8.RuneCountInString(value.String()), token) {
+				return errorValidation
+			}
+		default:
+			return errorSyntax
+		}
+
+		return nil
+	}
+}
+
+// validateString creates a validator checking a string with the given text.
+func validateString(validatorType Type, accept func(s, text string) bool) validatorFunc {
+	return func(value reflect.Value, validator string) ErrorField {
+		errorValidation := ErrorValidation{
+			fieldValue:     value,
+			validatorType:  validatorType,
+			validatorValue: validator,
+		}
+
+		errorSyntax := ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			if validator == "" {
+				return errorSyntax
+			} else if !accept(value.String(), validator) {
+				return errorValidation
+			}
+		default:
+			return errorSyntax
+		}
+
+		return nil
+	}
+}
+
+func validateRegex(value reflect.Value, validator string) ErrorField {
+	return validateRegexWith(value, validator, globalRegistry.regex)
+}
+
+// validateRegexWith validates a string with a regular expression found by lookupRegex.
+func validateRegexWith(value reflect.Value, validator string, lookupRegex func(string) (*regexp.Regexp, bool)) ErrorField {
+	kind := value.Kind()
+
+	errorValidation := ErrorValidation{
+		fieldValue:     value,
+		validatorType:  Regex,
+		validatorValue: validator,
+	}
+
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(Regex),
+		comment:    "could not find regex",
+	}
+
+	switch kind {
+	case reflect.String:
+		if re, ok := lookupRegex(validator); !ok {
+			return errorSyntax
+		} else if !re.MatchString(value.String()) {
+			return errorValidation
+		}
+	default:
+		return errorSyntax
+	}
+
+	return nil
+}
+
+func validateDatetime(value reflect.Value, validator string) ErrorField {
+	kind := value.Kind()
+
+	errorValidation := ErrorValidation{
+		fieldValue:     value,
+		validatorType:  Datetime,
+		validatorValue: validator,
+	}
+
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(Datetime),
+		comment:    "could not parse or run",
+	}
+
+	switch kind {
+	case reflect.String:
+		if validator == "" {
+			return errorSyntax
+		} else if _, err := time.Parse(validator, value.String()); err != nil {
+			return errorValidation
+		}
+	default:
+		return errorSyntax
+	}
+
+	return nil
+}
+
+// compareTimeToken compares a time.Time value with a token.
+// The token is a RFC3339 timestamp, a date like 2006-01-02, now or now with a duration, e.g. now-24h.
+// It returns false if the value is not a time.Time or the token could not be parsed.
+func compareTimeToken(value reflect.Value, token string) (int, bool) {
+	if value.Type() != timeType {
+		return 0, false
+	}
+
+	t, err := parseTimeToken(token, time.Now())
+	if err != nil {
+		return 0, false
+	}
+
+	v := timeOf(value)
+
+	return compareOrdered(v.Before(t), v.After(t)), true
+}
+
+// parseTimeToken parses a time token relative to now, see compareTimeToken.
+func parseTimeToken(token string, now time.Time) (time.Time, error) {
+	if strings.HasPrefix(token, "now") {
+		if offset := strings.TrimSpace(token[3:]); offset != "" {
+			d, err := time.ParseDuration(strings.Replace(offset, " ", "", -1))
+			if err != nil {
+				return time.Time{}, err
+			}
+
+			return now.Add(d), nil
+		}
+
+		return now, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, token); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02", token)
+}